@@ -26,8 +26,9 @@ import (
 )
 
 var (
-	DuckConditionReadyBlank     = diemetav1.ConditionBlank.Type(DuckConditionReady).Status(metav1.ConditionUnknown).Reason("Initializing")
-	DuckConditionAvailableBlank = diemetav1.ConditionBlank.Type(DuckConditionAvailable).Status(metav1.ConditionUnknown).Reason("Initializing")
+	DuckConditionReadyBlank      = diemetav1.ConditionBlank.Type(DuckConditionReady).Status(metav1.ConditionUnknown).Reason("Initializing")
+	DuckConditionAvailableBlank  = diemetav1.ConditionBlank.Type(DuckConditionAvailable).Status(metav1.ConditionUnknown).Reason("Initializing")
+	DuckConditionConformantBlank = diemetav1.ConditionBlank.Type(DuckConditionConformant).Status(metav1.ConditionUnknown).Reason("Initializing")
 )
 
 func (d *DuckStatusDie) InitializeConditions(now time.Time) *DuckStatusDie {
@@ -25,9 +25,10 @@ import (
 )
 
 const (
-	DuckConditionReady     = apis.ConditionReady
-	DuckConditionRBAC      = "RBAC"
-	DuckConditionAvailable = "Available"
+	DuckConditionReady      = apis.ConditionReady
+	DuckConditionRBAC       = "RBAC"
+	DuckConditionAvailable  = "Available"
+	DuckConditionConformant = "Conformant"
 )
 
 func (r *Duck) GetConditionsAccessor() apis.ConditionsAccessor {
@@ -43,6 +44,7 @@ func (r *DuckStatus) GetConditionSet() apis.ConditionSet {
 		"Ready",
 		DuckConditionRBAC,
 		DuckConditionAvailable,
+		DuckConditionConformant,
 	)
 }
 
@@ -26,8 +26,16 @@ const (
 	DuckTypeConditionReady                               = apis.ConditionReady
 	DuckTypeConditionRBAC                                = "RBAC"
 	DuckTypeConditionCustomResourceDefinitionEstablished = "CustomResourceDefinitionEstablished"
+	// DuckTypeConditionDuckController reports the submanager running the controller for the
+	// DuckType's Ducks. It does not gate Ready, a transient submanager failure must not make the
+	// DuckType unavailable to duck clients.
+	DuckTypeConditionDuckController = "DuckController"
 )
 
+// DuckTypeSchemaAnnotation is set on the CustomResourceDefinition generated for a DuckType with the
+// JSON encoded schema from the DuckType's spec.
+const DuckTypeSchemaAnnotation = "ducks.reconciler.io/schema"
+
 func (r *DuckType) GetConditionsAccessor() apis.ConditionsAccessor {
 	return &r.Status
 }
@@ -17,6 +17,7 @@ limitations under the License.
 package v1
 
 import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"reconciler.io/runtime/apis"
 )
@@ -41,6 +42,13 @@ type DuckTypeSpec struct {
 	// ListKind is the serialized kind of the list for this resource. Defaults to "<kind>List".
 	// +optional
 	ListKind string `json:"listKind,omitempty"`
+	// Schema is the OpenAPI v3 schema describing the shape of the duck type. Resources implementing
+	// the duck type are expected to conform to this schema.
+	// +optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Schema *apiextensionsv1.JSONSchemaProps `json:"schema,omitempty"`
 }
 
 // +die
@@ -115,6 +115,9 @@ func (r *DuckTypeSpec) Validate(ctx context.Context, fldPath *field.Path) field.
 		// defaulted
 		errs = append(errs, field.Required(fldPath.Child("listKind"), ""))
 	}
+	if r.Schema != nil && r.Schema.Type != "object" {
+		errs = append(errs, field.Invalid(fldPath.Child("schema", "type"), r.Schema.Type, "must be `object`"))
+	}
 
 	return errs
 }
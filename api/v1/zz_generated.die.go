@@ -27,6 +27,7 @@ import (
 	reflectx "reflect"
 
 	cmp "github.com/google/go-cmp/cmp"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apismetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	unstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	runtime "k8s.io/apimachinery/pkg/runtime"
@@ -1211,6 +1212,15 @@ func (d *DuckTypeSpecDie) ListKind(v string) *DuckTypeSpecDie {
 	})
 }
 
+// Schema is the OpenAPI v3 schema describing the shape of the duck type. Resources implementing
+//
+// the duck type are expected to conform to this schema.
+func (d *DuckTypeSpecDie) Schema(v *apiextensionsv1.JSONSchemaProps) *DuckTypeSpecDie {
+	return d.DieStamp(func(r *DuckTypeSpec) {
+		r.Schema = v
+	})
+}
+
 var DuckTypeStatusBlank = (&DuckTypeStatusDie{}).DieFeed(DuckTypeStatus{})
 
 type DuckTypeStatusDie struct {
@@ -0,0 +1,190 @@
+/*
+Copyright 2025 the original author or authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	appsv1ac "k8s.io/client-go/applyconfigurations/apps/v1"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"reconciler.io/runtime/apis"
+	"reconciler.io/runtime/reconcilers"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	duckv1 "reconciler.io/ducks/api/v1"
+	duckclient "reconciler.io/ducks/client"
+)
+
+func TestApply(t *testing.T) {
+	namespace := "test-namespace"
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(duckv1.AddToScheme(scheme))
+	scheme.AddKnownTypeWithName(schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "ConditionDuck"}, &duckv1.Duck{})
+	scheme.AddKnownTypeWithName(schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "ConditionDuckList"}, &duckv1.DuckList{})
+
+	duckType := &duckv1.DuckType{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "conditionducks.example.com",
+		},
+		Spec: duckv1.DuckTypeSpec{
+			Group:  "example.com",
+			Plural: "conditionducks",
+			Kind:   "ConditionDuck",
+		},
+		Status: duckv1.DuckTypeStatus{
+			Status: apis.Status{
+				Conditions: []metav1.Condition{
+					{Type: duckv1.DuckTypeConditionReady, Status: metav1.ConditionTrue},
+				},
+			},
+		},
+	}
+	deployment := func(apiVersion string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": apiVersion,
+				"kind":       "Deployment",
+				"metadata": map[string]interface{}{
+					"namespace": namespace,
+					"name":      "blue",
+				},
+			},
+		}
+	}
+
+	tests := map[string]struct {
+		obj func() runtime.ApplyConfiguration
+		// expectedAPIVersion of the applied configuration, nothing is applied when empty
+		expectedAPIVersion string
+		expectedErr        error
+	}{
+		"unstructured normalized version": {
+			obj: func() runtime.ApplyConfiguration {
+				return client.ApplyConfigurationFromUnstructured(deployment("apps/v1beta1"))
+			},
+			expectedAPIVersion: "apps/v1",
+		},
+		"unstructured unknown kind": {
+			obj: func() runtime.ApplyConfiguration {
+				job := deployment("batch/v1")
+				job.SetKind("Job")
+				return client.ApplyConfigurationFromUnstructured(job)
+			},
+			expectedErr: duckclient.ErrUnknownDuck,
+		},
+		"typed": {
+			obj: func() runtime.ApplyConfiguration {
+				return appsv1ac.Deployment("blue", namespace)
+			},
+			expectedAPIVersion: "apps/v1",
+		},
+		"typed version mismatch": {
+			obj: func() runtime.ApplyConfiguration {
+				ac := appsv1ac.Deployment("blue", namespace)
+				ac.WithAPIVersion("apps/v1beta1")
+				return ac
+			},
+		},
+	}
+	for name, tc := range tests {
+		for _, status := range []bool{false, true} {
+			subName := name
+			if status {
+				subName = name + " status"
+			}
+			t.Run(subName, func(t *testing.T) {
+				applier := &recordingApplier{
+					Client: fake.NewClientBuilder().
+						WithScheme(scheme).
+						WithObjects(duckType, readyDuck("deployments.apps", "apps", "v1", "Deployment")).
+						Build(),
+				}
+				c := duckclient.New(duckType.Name, reconcilers.Config{Client: applier})
+
+				var err error
+				if status {
+					err = c.Status().Apply(context.Background(), tc.obj())
+				} else {
+					err = c.Apply(context.Background(), tc.obj())
+				}
+				if tc.expectedAPIVersion == "" {
+					if err == nil {
+						t.Fatalf("expected to err")
+					}
+					if tc.expectedErr != nil && !errors.Is(err, tc.expectedErr) {
+						t.Errorf("expected err %q, got %q", tc.expectedErr, err)
+					}
+				} else if err != nil {
+					t.Fatalf("unexpected err: %s", err)
+				}
+
+				var expected []string
+				if tc.expectedAPIVersion != "" {
+					expected = append(expected, tc.expectedAPIVersion)
+				}
+				if diff := cmp.Diff(expected, applier.applied); diff != "" {
+					t.Errorf("unexpected applied api versions (-expected, +actual): %s", diff)
+				}
+			})
+		}
+	}
+}
+
+// recordingApplier records the api version of each apply configuration rather than applying it
+type recordingApplier struct {
+	client.Client
+	applied []string
+}
+
+func (c *recordingApplier) Apply(ctx context.Context, obj runtime.ApplyConfiguration, opts ...client.ApplyOption) error {
+	c.record(obj)
+	return nil
+}
+
+func (c *recordingApplier) Status() client.SubResourceWriter {
+	return &recordingStatusApplier{SubResourceWriter: c.Client.Status(), applier: c}
+}
+
+func (c *recordingApplier) record(obj runtime.ApplyConfiguration) {
+	switch ac := obj.(type) {
+	case interface{ GetObjectKind() schema.ObjectKind }:
+		c.applied = append(c.applied, ac.GetObjectKind().GroupVersionKind().GroupVersion().String())
+	case interface{ GetAPIVersion() *string }:
+		c.applied = append(c.applied, *ac.GetAPIVersion())
+	}
+}
+
+type recordingStatusApplier struct {
+	client.SubResourceWriter
+	applier *recordingApplier
+}
+
+func (w *recordingStatusApplier) Apply(ctx context.Context, obj runtime.ApplyConfiguration, opts ...client.SubResourceApplyOption) error {
+	w.applier.record(obj)
+	return nil
+}
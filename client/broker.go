@@ -19,12 +19,22 @@ package client
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/go-logr/logr"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
 	toolscache "k8s.io/client-go/tools/cache"
 	"reconciler.io/runtime/apis"
 	"reconciler.io/runtime/reconcilers"
@@ -33,197 +43,733 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	duckv1 "reconciler.io/ducks/api/v1"
 )
 
 type Broker interface {
-	Subscribe(ctx context.Context) <-chan event.GenericEvent
-	TrackedSource(ctx context.Context) source.Source
+	// Subscribe to generic events for resources implementing the duck type
+	Subscribe(ctx context.Context, opts ...SubscribeOption) <-chan event.GenericEvent
+	// SubscribeTyped subscribes to events that describe the operation, the prior state of updated
+	// resources and the duck producing the event
+	SubscribeTyped(ctx context.Context, opts ...SubscribeOption) <-chan DuckEvent
+	TrackedSource(ctx context.Context, opts ...SubscribeOption) source.Source
+	// Synced is true once the existing ducks have been handled and the informers for every ready
+	// duck have delivered their existing objects
+	Synced() bool
+	// WaitForSync blocks until the broker's informers have synced, or the context is done
+	WaitForSync(ctx context.Context) error
+	// Checker fails until the broker's informers have synced, compatible with healthz.Checker
+	Checker(req *http.Request) error
 }
 
-// NewBroker starts informers for all resources of a given duck type
-func NewBroker(mgr manager.Manager, duck schema.GroupKind) (Broker, error) {
+// SubscribeOption filters the events received by a subscriber. Filters are evaluated by the
+// broker before the event is sent to the subscriber and must not block.
+type SubscribeOption func(*subscriber)
+
+// SubscribeNamespace only receives events for resources in the namespace. Ducks are cluster
+// scoped, DuckAdded and DuckRemoved events are always received.
+func SubscribeNamespace(namespace string) SubscribeOption {
+	return func(s *subscriber) {
+		s.filters = append(s.filters, func(msg DuckEvent) bool {
+			return msg.IsDuckEvent() || msg.Object.GetNamespace() == namespace
+		})
+	}
+}
+
+// SubscribeLabelSelector only receives events for resources matching the selector. For updates,
+// either the old or new object may match. DuckAdded and DuckRemoved events are always received.
+func SubscribeLabelSelector(selector labels.Selector) SubscribeOption {
+	return func(s *subscriber) {
+		s.filters = append(s.filters, func(msg DuckEvent) bool {
+			if msg.IsDuckEvent() || selector.Matches(labels.Set(msg.Object.GetLabels())) {
+				return true
+			}
+			return msg.OldObject != nil && selector.Matches(labels.Set(msg.OldObject.GetLabels()))
+		})
+	}
+}
+
+// SubscribeDuckType only receives events for the duck type, identified by the group and kind of
+// the DuckType's Duck resource
+func SubscribeDuckType(duckType schema.GroupKind) SubscribeOption {
+	return func(s *subscriber) {
+		s.filters = append(s.filters, func(msg DuckEvent) bool {
+			return msg.DuckType == duckType
+		})
+	}
+}
+
+// SubscribeGroupKind only receives events for resources of the implementer's GroupKind
+func SubscribeGroupKind(gk schema.GroupKind) SubscribeOption {
+	return func(s *subscriber) {
+		s.filters = append(s.filters, func(msg DuckEvent) bool {
+			return msg.Duck != nil && msg.Duck.Spec.Group == gk.Group && msg.Duck.Spec.Kind == gk.Kind
+		})
+	}
+}
+
+// SubscribePredicates only receives events accepted by every predicate. Predicates are evaluated
+// with the event matching the operation.
+func SubscribePredicates(predicates ...predicate.Predicate) SubscribeOption {
+	return func(s *subscriber) {
+		s.filters = append(s.filters, func(msg DuckEvent) bool {
+			for _, p := range predicates {
+				var ok bool
+				switch msg.Type {
+				case DuckEventCreate:
+					ok = p.Create(event.CreateEvent{Object: msg.Object})
+				case DuckEventUpdate:
+					ok = p.Update(event.UpdateEvent{ObjectOld: msg.OldObject, ObjectNew: msg.Object})
+				case DuckEventDelete:
+					ok = p.Delete(event.DeleteEvent{Object: msg.Object})
+				default:
+					ok = p.Generic(event.GenericEvent{Object: msg.Object})
+				}
+				if !ok {
+					return false
+				}
+			}
+			return true
+		})
+	}
+}
+
+// DuckEventType is the operation observed for a resource
+type DuckEventType string
+
+const (
+	DuckEventCreate DuckEventType = "Create"
+	DuckEventUpdate DuckEventType = "Update"
+	DuckEventDelete DuckEventType = "Delete"
+	// DuckEventDuckAdded is published when a duck becomes ready, the event's object is the duck
+	DuckEventDuckAdded DuckEventType = "DuckAdded"
+	// DuckEventDuckRemoved is published when a duck is deleted or is no longer ready, the event's
+	// object is the duck
+	DuckEventDuckRemoved DuckEventType = "DuckRemoved"
+)
+
+// DuckEvent is a change to a resource implementing the duck type, or to the set of ducks
+// implementing the duck type
+type DuckEvent struct {
+	Type DuckEventType
+	// Object is the current state of the resource, or the last known state when deleted
+	Object client.Object
+	// OldObject is the prior state of the resource for updates
+	OldObject client.Object
+	// Duck marks the resource's API as implementing the duck type
+	Duck *duckv1.Duck
+	// DuckType is the group and kind of the duck type's Duck resource
+	DuckType schema.GroupKind
+}
+
+// IsDuckEvent is true for events about a duck rather than a resource implementing the duck
+func (e DuckEvent) IsDuckEvent() bool {
+	return e.Type == DuckEventDuckAdded || e.Type == DuckEventDuckRemoved
+}
+
+// BrokerOption customizes the broker
+type BrokerOption func(*broker)
+
+// WithMetadataOnly informs on PartialObjectMetadata for resources implementing the duck type
+// rather than full objects. Events published by the broker carry *metav1.PartialObjectMetadata.
+func WithMetadataOnly() BrokerOption {
+	return func(b *broker) {
+		b.metadataOnly = true
+	}
+}
+
+// WithCacheOptions creates the broker's cache with the options, typically the options used for
+// the manager's cache so the broker observes the same namespaces, selectors and sync period. The
+// HTTP client, scheme and mapper default to the manager's. Required, the broker's cache can not
+// discover how the manager's cache is scoped.
+func WithCacheOptions(opts cache.Options) BrokerOption {
+	return func(b *broker) {
+		b.cacheOpts = &opts
+	}
+}
+
+// NewBrokerForDuckType starts informers for all resources of the named duck type. The DuckType
+// must exist and be ready, it is read from the API server with the context.
+func NewBrokerForDuckType(ctx context.Context, mgr manager.Manager, duckType string, opts ...BrokerOption) (Broker, error) {
+	return NewBrokerForDuckTypes(ctx, mgr, []string{duckType}, opts...)
+}
+
+// NewBrokerForDuckTypes starts informers for all resources of each named duck type. The
+// DuckTypes must exist and be ready, they are read from the API server with the context.
+func NewBrokerForDuckTypes(ctx context.Context, mgr manager.Manager, duckTypes []string, opts ...BrokerOption) (Broker, error) {
+	ducks := make([]schema.GroupKind, len(duckTypes))
+	for i, duckType := range duckTypes {
+		r := &duckv1.DuckType{}
+		if err := mgr.GetAPIReader().Get(ctx, client.ObjectKey{Name: duckType}, r); err != nil {
+			if apierrs.IsNotFound(err) {
+				return nil, fmt.Errorf("%w: %s", ErrUnknownDuckType, duckType)
+			}
+			return nil, err
+		}
+		if err := r.Default(ctx, r); err != nil {
+			return nil, err
+		}
+		if ready := r.GetConditionManager(ctx).GetCondition(duckv1.DuckTypeConditionReady); !apis.ConditionIsTrue(ready) {
+			return nil, fmt.Errorf("%w: %s", ErrDuckTypeNotReady, duckType)
+		}
+		ducks[i] = schema.GroupKind{Group: r.Spec.Group, Kind: r.Spec.Kind}
+	}
+
+	return NewMultiBroker(mgr, ducks, opts...)
+}
+
+// NewBroker starts informers for all resources of a given duck type. The GroupKind is the group and
+// kind from the DuckType's spec, without a version.
+func NewBroker(mgr manager.Manager, duck schema.GroupKind, opts ...BrokerOption) (Broker, error) {
+	return NewMultiBroker(mgr, []schema.GroupKind{duck}, opts...)
+}
+
+// NewMultiBroker starts informers for all resources of each duck type. Informers for resources
+// implementing more than one of the duck types are shared, events are published for each duck
+// type and tagged with the duck type. The informers are kept in a cache owned by the broker,
+// created with the options from WithCacheOptions.
+func NewMultiBroker(mgr manager.Manager, ducks []schema.GroupKind, opts ...BrokerOption) (Broker, error) {
+	if len(ducks) == 0 {
+		return nil, fmt.Errorf("at least one duck type is required")
+	}
+	kinds := make([]string, len(ducks))
+	for i, duck := range ducks {
+		kinds[i] = duck.Kind
+	}
 	broker := &broker{
-		name:      fmt.Sprintf("%sBroker", duck.Kind),
-		publishCh: make(chan event.GenericEvent, 1),
-		subCh:     make(chan chan event.GenericEvent, 1),
-		unsubCh:   make(chan chan event.GenericEvent, 1),
+		name:                  fmt.Sprintf("%sBroker", strings.Join(kinds, "")),
+		publishCh:             make(chan DuckEvent, 1),
+		subCh:                 make(chan *subscriber, 1),
+		unsubCh:               make(chan *subscriber, 1),
+		duckTypes:             ducks,
+		duckTypeRegistrations: map[schema.GroupKind]toolscache.ResourceEventHandlerRegistration{},
+		duckInformers:         map[duckInformerKey]*duckInformer{},
+		informerRefs:          map[informerKey]int{},
+		schemas:               map[schema.GroupVersionKind]map[schema.GroupKind]*apiextensionsv1.JSONSchemaProps{},
+	}
+	for _, opt := range opts {
+		opt(broker)
 	}
+	if broker.cacheOpts == nil {
+		// defaulting to cluster wide informers would fail for a manager limited to namespaces or
+		// selectors
+		return nil, fmt.Errorf("%w, pass the options used for the manager's cache with WithCacheOptions", ErrCacheOptionsRequired)
+	}
+	// duck informers are kept in a cache owned by the broker so they can be removed without
+	// affecting informers shared by other users of the manager's cache. Informers in the
+	// manager's cache may also already be started and can not be transformed.
+	cacheOpts := *broker.cacheOpts
+	if cacheOpts.HTTPClient == nil {
+		cacheOpts.HTTPClient = mgr.GetHTTPClient()
+	}
+	if cacheOpts.Scheme == nil {
+		cacheOpts.Scheme = mgr.GetScheme()
+	}
+	if cacheOpts.Mapper == nil {
+		cacheOpts.Mapper = mgr.GetRESTMapper()
+	}
+	if broker.transforms() {
+		defaultTransform := cacheOpts.DefaultTransform
+		cacheOpts.DefaultTransform = func(obj interface{}) (interface{}, error) {
+			obj, err := broker.transformObject(obj)
+			if err != nil || defaultTransform == nil {
+				return obj, err
+			}
+			return defaultTransform(obj)
+		}
+	}
+	c, err := cache.New(mgr.GetConfig(), cacheOpts)
+	if err != nil {
+		return nil, err
+	}
+	if err := mgr.Add(c); err != nil {
+		return nil, err
+	}
+	broker.cache = c
 	ctx, cancel := context.WithCancel(context.Background())
+	broker.done = ctx.Done()
+	broker.cancel = cancel
 	go broker.Start(ctx)
 
-	mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
-		log := ctrl.Log.WithName(broker.name)
+	for _, duck := range ducks {
+		if err := mgr.Add(broker.informOnDuckType(mgr, duck)); err != nil {
+			cancel()
+			return nil, err
+		}
+	}
+
+	return broker, nil
+}
+
+// informOnDuckType starts informers for the resources of each ready duck of the duck type
+func (b *broker) informOnDuckType(mgr manager.Manager, duckType schema.GroupKind) manager.Runnable {
+	return manager.RunnableFunc(func(ctx context.Context) error {
+		log := ctrl.Log.WithName(b.name).WithValues("duckType", duckType)
 		ctx = logr.NewContext(ctx, log)
 
 		log.Info("Starting")
 
 		u := &unstructured.Unstructured{}
-		u.SetGroupVersionKind(duck.WithVersion("v1"))
+		u.SetGroupVersionKind(duckType.WithVersion("v1"))
 		duckTypeInformer, err := mgr.GetCache().GetInformer(ctx, u, cache.BlockUntilSynced(false))
 		if err != nil {
 			return err
 		}
 
-		var m sync.Mutex
-		duckInformers := map[string]cache.Informer{}
-		duckRegistrations := map[string]toolscache.ResourceEventHandlerRegistration{}
+		// duckTypeSchema is nil until the DuckType is observed, objects are not pruned until the
+		// schema is known. Guarded by the broker's lock.
+		var duckTypeSchema *apiextensionsv1.JSONSchemaProps
+
+		// stopInforming removes the informer for the duck, must be called while holding the lock.
+		// Returns the DuckRemoved event to publish once the lock is released, publishing may block
+		// on the broker loop.
+		var stopInforming = func(name string) (DuckEvent, bool) {
+			key := duckInformerKey{duckType: duckType, name: name}
+			di, ok := b.duckInformers[key]
+			if !ok {
+				return DuckEvent{}, false
+			}
+			log := log.WithValues("duck", name)
+
+			log.Info("Stopping duck informer")
+
+			delete(b.duckInformers, key)
+			if err := di.informer.RemoveEventHandler(di.registration); err != nil {
+				log.Error(err, "Unable to stop duck informer")
+			}
+			if err := b.releaseInformer(ctx, di.obj); err != nil {
+				log.Error(err, "Unable to remove duck informer")
+			}
+			b.removeSchema(di.gvk, duckType)
+
+			return DuckEvent{Type: DuckEventDuckRemoved, Object: duckObject(di.duck), Duck: di.duck, DuckType: duckType}, true
+		}
 
 		var informOn = func(r *duckv1.Duck) {
 			log := log.WithValues("duck", r.Name)
 
-			m.Lock()
-			defer m.Unlock()
+			var published []DuckEvent
+			b.m.Lock()
+			defer func() {
+				b.m.Unlock()
+				for _, msg := range published {
+					b.Publish(msg)
+				}
+			}()
 
-			if _, ok := duckInformers[r.Name]; ok {
-				// already informing
-				return
-			}
 			if ready := r.GetConditionManager(ctx).GetCondition(duckv1.DuckConditionReady); !apis.ConditionIsTrue(ready) {
 				// not ready
+				if msg, ok := stopInforming(r.Name); ok {
+					published = append(published, msg)
+				}
 				return
 			}
+			gvk := r.Spec.GroupVersionKind()
+			restart := false
+			key := duckInformerKey{duckType: duckType, name: r.Name}
+			if di, ok := b.duckInformers[key]; ok {
+				if di.gvk == gvk && di.schema == duckTypeSchema {
+					// already informing
+					return
+				}
+				// the implementing resource or the schema changed, restart the informer
+				stopInforming(r.Name)
+				restart = true
+			}
 
 			log.Info("Starting duck informer")
 
-			u := &unstructured.Unstructured{}
-			u.SetGroupVersionKind(r.Spec.GroupVersionKind())
-			duckInformer, err := mgr.GetCache().GetInformer(ctx, u, cache.BlockUntilSynced(false))
+			var obj client.Object = &unstructured.Unstructured{}
+			if b.metadataOnly {
+				obj = &metav1.PartialObjectMetadata{}
+			}
+			obj.GetObjectKind().SetGroupVersionKind(gvk)
+			// the schema must be known before the informer receives objects to transform
+			b.setSchema(gvk, duckType, duckTypeSchema)
+			informer, err := b.acquireInformer(ctx, obj)
 			if err != nil {
 				log.Error(err, "Unable to start duck informer")
+				b.removeSchema(gvk, duckType)
 				return
 			}
-			duckRegistration, err := duckInformer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+			duck := r.DeepCopy()
+			registration, err := informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
 				AddFunc: func(obj interface{}) {
-					broker.Publish(event.GenericEvent{Object: obj.(client.Object)})
+					b.Publish(DuckEvent{Type: DuckEventCreate, Object: obj.(client.Object), Duck: duck, DuckType: duckType})
 				},
 				UpdateFunc: func(oldObj, newObj interface{}) {
-					broker.Publish(event.GenericEvent{Object: newObj.(client.Object)})
+					b.Publish(DuckEvent{Type: DuckEventUpdate, Object: newObj.(client.Object), OldObject: oldObj.(client.Object), Duck: duck, DuckType: duckType})
 				},
 				DeleteFunc: func(obj interface{}) {
-					broker.Publish(event.GenericEvent{Object: obj.(client.Object)})
+					if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+						obj = tombstone.Obj
+					}
+					if obj, ok := obj.(client.Object); ok {
+						b.Publish(DuckEvent{Type: DuckEventDelete, Object: obj, Duck: duck, DuckType: duckType})
+					}
 				},
 			})
 			if err != nil {
 				log.Error(err, "Unable to handle duck events")
+				if err := b.releaseInformer(ctx, obj); err != nil {
+					log.Error(err, "Unable to remove duck informer")
+				}
+				b.removeSchema(gvk, duckType)
 				return
 			}
-			duckInformers[r.Name] = duckInformer
-			duckRegistrations[r.Name] = duckRegistration
+			b.duckInformers[key] = &duckInformer{
+				duck:         duck,
+				gvk:          gvk,
+				schema:       duckTypeSchema,
+				obj:          obj,
+				informer:     informer,
+				registration: registration,
+			}
+			if !restart {
+				published = append(published, DuckEvent{Type: DuckEventDuckAdded, Object: duckObject(duck), Duck: duck, DuckType: duckType})
+			}
+		}
+
+		// onDuck informs on a duck, malformed ducks are skipped
+		var onDuck = func(obj interface{}) {
+			r, err := convertDuck(obj)
+			if err != nil {
+				log.Error(err, "Unable to convert duck, skipping")
+				brokerDuckErrors.WithLabelValues(b.name).Inc()
+				return
+			}
+			informOn(r)
 		}
 
 		duckTypeRegistration, err := duckTypeInformer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
 			AddFunc: func(obj interface{}) {
-				u := obj.(*unstructured.Unstructured)
-				r := &duckv1.Duck{}
-				if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, r); err != nil {
-					panic(err)
-				}
-				informOn(r)
+				onDuck(obj)
 			},
 			UpdateFunc: func(oldObj, newObj interface{}) {
-				u := newObj.(*unstructured.Unstructured)
-				r := &duckv1.Duck{}
-				if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, r); err != nil {
-					panic(err)
-				}
-				informOn(r)
+				onDuck(newObj)
 			},
 			DeleteFunc: func(obj interface{}) {
-				name := obj.(*unstructured.Unstructured).GetName()
-				log := log.WithValues("duck", name)
-
-				log.Info("Stopping duck informer")
-
-				m.Lock()
-				defer m.Unlock()
+				if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+					obj = tombstone.Obj
+				}
+				u, ok := obj.(*unstructured.Unstructured)
+				if !ok {
+					return
+				}
 
-				if registration, ok := duckRegistrations[name]; ok {
-					if err := duckInformers[name].RemoveEventHandler(registration); err != nil {
-						log.Error(err, "Unable to stop duck informer")
-						return
-					}
-					delete(duckInformers, name)
-					delete(duckRegistrations, name)
+				b.m.Lock()
+				msg, ok := stopInforming(u.GetName())
+				b.m.Unlock()
+				if ok {
+					b.Publish(msg)
 				}
 			},
 		})
 		if err != nil {
 			return err
 		}
+		b.m.Lock()
+		b.duckTypeRegistrations[duckType] = duckTypeRegistration
+		b.m.Unlock()
+
+		var schemaInformer cache.Informer
+		var schemaRegistration toolscache.ResourceEventHandlerRegistration
+		if b.prune {
+			// onSchema restarts the duck informers when the DuckType's schema changes, so objects
+			// are listed again and pruned with the new schema. An informer shared with another
+			// duck type of the broker keeps its cached objects until they are updated.
+			var onSchema = func(obj interface{}) {
+				r, err := convertDuckType(obj)
+				if err != nil {
+					log.Error(err, "Unable to convert duck type, skipping")
+					return
+				}
+				if r.Spec.Group != duckType.Group || r.Spec.Kind != duckType.Kind {
+					return
+				}
+
+				b.m.Lock()
+				if equality.Semantic.DeepEqual(duckTypeSchema, r.Spec.Schema) {
+					b.m.Unlock()
+					return
+				}
+				log.Info("Duck type schema changed")
+				duckTypeSchema = r.Spec.Schema
+				ducks := []*duckv1.Duck{}
+				for key, di := range b.duckInformers {
+					if key.duckType == duckType {
+						ducks = append(ducks, di.duck)
+					}
+				}
+				b.m.Unlock()
+
+				for _, duck := range ducks {
+					informOn(duck)
+				}
+			}
+
+			u := &unstructured.Unstructured{}
+			u.SetGroupVersionKind(duckv1.GroupVersion.WithKind("DuckType"))
+			if schemaInformer, err = mgr.GetCache().GetInformer(ctx, u, cache.BlockUntilSynced(false)); err != nil {
+				log.Error(err, "Unable to inform on duck types, objects will not be pruned")
+			} else if schemaRegistration, err = schemaInformer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+				AddFunc: func(obj interface{}) {
+					onSchema(obj)
+				},
+				UpdateFunc: func(oldObj, newObj interface{}) {
+					onSchema(newObj)
+				},
+			}); err != nil {
+				log.Error(err, "Unable to handle duck type events, objects will not be pruned")
+				schemaInformer = nil
+			}
+		}
 
 		log.Info("Started")
 		<-ctx.Done()
 
-		m.Lock()
-		defer m.Unlock()
+		b.m.Lock()
+		defer b.m.Unlock()
 
-		cancel()
+		b.cancel()
+		delete(b.duckTypeRegistrations, duckType)
 
 		// remove handlers
+		if schemaInformer != nil {
+			if err := schemaInformer.RemoveEventHandler(schemaRegistration); err != nil {
+				return err
+			}
+		}
 		if err := duckTypeInformer.RemoveEventHandler(duckTypeRegistration); err != nil {
 			return err
 		}
-		for name, registration := range duckRegistrations {
-			if err := duckInformers[name].RemoveEventHandler(registration); err != nil {
-				return err
+		for key := range b.duckInformers {
+			if key.duckType == duckType {
+				stopInforming(key.name)
 			}
 		}
 
 		return nil
-	}))
+	})
+}
 
-	return broker, nil
+// convertDuckType converts an object from the informer into a duck type
+func convertDuckType(obj interface{}) (*duckv1.DuckType, error) {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return nil, fmt.Errorf("unexpected object type %T", obj)
+	}
+	r := &duckv1.DuckType{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, r); err != nil {
+		return nil, fmt.Errorf("duck type %q: %w", u.GetName(), err)
+	}
+	return r, nil
+}
+
+// convertDuck converts an object from the informer into a duck
+func convertDuck(obj interface{}) (*duckv1.Duck, error) {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return nil, fmt.Errorf("unexpected object type %T", obj)
+	}
+	r := &duckv1.Duck{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, r); err != nil {
+		return nil, fmt.Errorf("duck %q: %w", u.GetName(), err)
+	}
+	return r, nil
+}
+
+// duckObject returns the duck in the unstructured form observed by the informer, so that it is
+// resolved by trackers without the duck type being registered with the scheme
+func duckObject(duck *duckv1.Duck) client.Object {
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(duck)
+	if err != nil {
+		return duck
+	}
+	return &unstructured.Unstructured{Object: u}
+}
+
+// duckInformerKey identifies a duck of a duck type, a resource may implement many duck types
+type duckInformerKey struct {
+	duckType schema.GroupKind
+	name     string
+}
+
+// duckInformer is the informer for resources implementing a duck
+type duckInformer struct {
+	duck         *duckv1.Duck
+	gvk          schema.GroupVersionKind
+	schema       *apiextensionsv1.JSONSchemaProps
+	obj          client.Object
+	informer     cache.Informer
+	registration toolscache.ResourceEventHandlerRegistration
 }
 
 // adapted from https://stackoverflow.com/a/49877632
 type broker struct {
-	name      string
-	publishCh chan event.GenericEvent
-	subCh     chan chan event.GenericEvent
-	unsubCh   chan chan event.GenericEvent
+	name         string
+	metadataOnly bool
+	publishCh    chan DuckEvent
+	subCh        chan *subscriber
+	unsubCh      chan *subscriber
+	subscribers  atomic.Int64
+	done         <-chan struct{}
+	cancel       context.CancelFunc
+
+	transform         toolscache.TransformFunc
+	prune             bool
+	keepManagedFields bool
+	cacheOpts         *cache.Options
+	// cache for duck informers, owned by the broker
+	cache cache.Cache
+
+	schemaM sync.RWMutex
+	// schemas of the duck types implemented by resources of each gvk
+	schemas map[schema.GroupVersionKind]map[schema.GroupKind]*apiextensionsv1.JSONSchemaProps
+
+	m         sync.Mutex
+	duckTypes []schema.GroupKind
+	// duckTypeRegistrations have synced once every existing duck has been handled
+	duckTypeRegistrations map[schema.GroupKind]toolscache.ResourceEventHandlerRegistration
+	duckInformers         map[duckInformerKey]*duckInformer
+	// informerRefs counts the duck informers using each informer in the broker's cache, shared
+	// by implementers of more than one duck type
+	informerRefs map[informerKey]int
+}
+
+// subscriber receives events from the broker. Deliver is called from the broker loop, only the
+// DeliveryBlock policy may block it.
+type subscriber struct {
+	name    string
+	policy  DeliveryPolicy
+	filters []func(DuckEvent) bool
+	deliver func(DuckEvent)
+	// release is called once the broker no longer delivers to the subscriber
+	release func()
+}
+
+func (s *subscriber) accepts(msg DuckEvent) bool {
+	for _, filter := range s.filters {
+		if !filter(msg) {
+			return false
+		}
+	}
+	return true
 }
 
 func (b *broker) Start(ctx context.Context) error {
-	subs := map[chan event.GenericEvent]struct{}{}
+	subs := map[*subscriber]struct{}{}
 	for {
 		select {
 		case <-ctx.Done():
 			return nil
-		case msgCh := <-b.subCh:
-			subs[msgCh] = struct{}{}
-		case msgCh := <-b.unsubCh:
-			delete(subs, msgCh)
+		case sub := <-b.subCh:
+			subs[sub] = struct{}{}
+		case sub := <-b.unsubCh:
+			delete(subs, sub)
 		case msg := <-b.publishCh:
-			for msgCh := range subs {
-				// msgCh is buffered, use non-blocking send to protect the broker:
-				select {
-				case msgCh <- msg:
-				default:
+			for sub := range subs {
+				if sub.accepts(msg) {
+					sub.deliver(msg)
 				}
 			}
 		}
 	}
 }
 
-func (b *broker) Publish(msg event.GenericEvent) {
-	b.publishCh <- msg
+func (b *broker) Publish(msg DuckEvent) {
+	select {
+	case b.publishCh <- msg:
+	case <-b.done:
+	}
 }
 
-func (b *broker) Subscribe(ctx context.Context) <-chan event.GenericEvent {
-	logr.FromContextOrDiscard(ctx).WithName(b.name).Info("Subscribe")
+func (b *broker) newSubscriber(opts []SubscribeOption) *subscriber {
+	sub := &subscriber{
+		name:   fmt.Sprintf("%d", b.subscribers.Add(1)),
+		policy: DeliveryDropNewest,
+	}
+	for _, opt := range opts {
+		opt(sub)
+	}
+	return sub
+}
 
-	msgCh := make(chan event.GenericEvent, 5)
-	b.subCh <- msgCh
+func (b *broker) subscribe(ctx context.Context, sub *subscriber) {
+	select {
+	case b.subCh <- sub:
+	case <-b.done:
+		// the broker is stopped, nothing will be delivered
+	}
 	go func() {
 		<-ctx.Done()
-		b.unsubCh <- msgCh
+		select {
+		case b.unsubCh <- sub:
+		case <-b.done:
+		}
+		sub.release()
 	}()
+}
+
+func (b *broker) Subscribe(ctx context.Context, opts ...SubscribeOption) <-chan event.GenericEvent {
+	sub := b.newSubscriber(opts)
+	logr.FromContextOrDiscard(ctx).WithName(b.name).Info("Subscribe", "subscriber", sub.name, "policy", sub.policy)
+
+	msgCh := deliverTo(ctx, b, sub, func(msg DuckEvent) event.GenericEvent {
+		return event.GenericEvent{Object: msg.Object}
+	})
+	b.subscribe(ctx, sub)
 	return msgCh
 }
 
-func (b *broker) TrackedSource(ctx context.Context) source.Source {
-	return source.Channel(b.Subscribe(ctx), reconcilers.EnqueueTracked(ctx))
+func (b *broker) SubscribeTyped(ctx context.Context, opts ...SubscribeOption) <-chan DuckEvent {
+	sub := b.newSubscriber(opts)
+	logr.FromContextOrDiscard(ctx).WithName(b.name).Info("SubscribeTyped", "subscriber", sub.name, "policy", sub.policy)
+
+	msgCh := deliverTo(ctx, b, sub, func(msg DuckEvent) DuckEvent {
+		return msg
+	})
+	b.subscribe(ctx, sub)
+	return msgCh
+}
+
+// Synced checks the event handler registrations rather than the informers. An informer syncs once
+// its store is populated, before the existing objects have been delivered to the broker's
+// handlers.
+func (b *broker) Synced() bool {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	for _, duckType := range b.duckTypes {
+		registration, ok := b.duckTypeRegistrations[duckType]
+		if !ok || !registration.HasSynced() {
+			return false
+		}
+	}
+	for _, di := range b.duckInformers {
+		if !di.registration.HasSynced() {
+			return false
+		}
+	}
+	return true
+}
+
+func (b *broker) WaitForSync(ctx context.Context) error {
+	return wait.PollUntilContextCancel(ctx, 100*time.Millisecond, true, func(ctx context.Context) (bool, error) {
+		return b.Synced(), nil
+	})
+}
+
+func (b *broker) Checker(req *http.Request) error {
+	if !b.Synced() {
+		return fmt.Errorf("%s informers have not synced", b.name)
+	}
+	return nil
+}
+
+func (b *broker) TrackedSource(ctx context.Context, opts ...SubscribeOption) source.Source {
+	return source.Channel(b.Subscribe(ctx, opts...), reconcilers.EnqueueTracked(ctx))
 }
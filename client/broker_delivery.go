@@ -0,0 +1,228 @@
+/*
+Copyright 2025 the original author or authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// DeliveryPolicy defines how the broker sends events to a subscriber that is not keeping up
+type DeliveryPolicy string
+
+const (
+	// DeliveryDropNewest discards the new event when the subscriber's buffer is full
+	DeliveryDropNewest DeliveryPolicy = "DropNewest"
+	// DeliveryDropOldest discards the oldest buffered event to make room for the new event
+	DeliveryDropOldest DeliveryPolicy = "DropOldest"
+	// DeliveryBlock waits for the subscriber to receive the event. A slow subscriber delays
+	// delivery to every other subscriber of the broker.
+	DeliveryBlock DeliveryPolicy = "Block"
+	// DeliveryCoalesce queues events without bound, keeping only the latest pending event for
+	// each object
+	DeliveryCoalesce DeliveryPolicy = "Coalesce"
+)
+
+// SubscribeDeliveryPolicy sets how events are sent to the subscriber, defaults to
+// DeliveryDropNewest
+func SubscribeDeliveryPolicy(policy DeliveryPolicy) SubscribeOption {
+	return func(s *subscriber) {
+		s.policy = policy
+	}
+}
+
+// SubscribeName identifies the subscriber in metrics and logs
+func SubscribeName(name string) SubscribeOption {
+	return func(s *subscriber) {
+		s.name = name
+	}
+}
+
+// deliverTo configures the subscriber to send events to a buffered channel according to the
+// subscriber's delivery policy
+func deliverTo[T any](ctx context.Context, b *broker, sub *subscriber, convert func(DuckEvent) T) <-chan T {
+	delivered := brokerEventsDelivered.WithLabelValues(b.name, sub.name)
+	coalesced := brokerEventsCoalesced.WithLabelValues(b.name, sub.name)
+	dropped := brokerEventsDropped.WithLabelValues(b.name, sub.name)
+	sub.release = func() {
+		// subscriber names are not reused, the series would otherwise accumulate
+		brokerEventsDelivered.DeleteLabelValues(b.name, sub.name)
+		brokerEventsCoalesced.DeleteLabelValues(b.name, sub.name)
+		brokerEventsDropped.DeleteLabelValues(b.name, sub.name)
+	}
+
+	msgCh := make(chan T, 5)
+
+	switch sub.policy {
+	case DeliveryBlock:
+		sub.deliver = func(msg DuckEvent) {
+			select {
+			case msgCh <- convert(msg):
+				delivered.Inc()
+			case <-ctx.Done():
+				dropped.Inc()
+			}
+		}
+	case DeliveryDropOldest:
+		sub.deliver = func(msg DuckEvent) {
+			v := convert(msg)
+			for {
+				select {
+				case msgCh <- v:
+					delivered.Inc()
+					return
+				default:
+				}
+				// make room by discarding the oldest event
+				select {
+				case <-msgCh:
+					dropped.Inc()
+				default:
+				}
+			}
+		}
+	case DeliveryCoalesce:
+		queue := newCoalescingQueue()
+		sub.deliver = func(msg DuckEvent) {
+			if queue.push(msg) {
+				coalesced.Inc()
+			}
+		}
+		stopped := make(chan struct{})
+		release := sub.release
+		sub.release = func() {
+			// the sender counts deliveries until it stops
+			<-stopped
+			release()
+		}
+		go func() {
+			defer close(stopped)
+			for {
+				msg, ok := queue.pop(ctx)
+				if !ok {
+					return
+				}
+				select {
+				case msgCh <- convert(msg):
+					delivered.Inc()
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	default:
+		sub.deliver = func(msg DuckEvent) {
+			// msgCh is buffered, use non-blocking send to protect the broker:
+			select {
+			case msgCh <- convert(msg):
+				delivered.Inc()
+			default:
+				dropped.Inc()
+			}
+		}
+	}
+
+	return msgCh
+}
+
+type coalescingKey struct {
+	duckType schema.GroupKind
+	schema.GroupKind
+	types.NamespacedName
+}
+
+// coalescingQueue is an unbounded FIFO queue that holds at most one pending event per object. A
+// newer event for an object replaces the pending event while keeping its position in the queue.
+type coalescingQueue struct {
+	m       sync.Mutex
+	keys    []coalescingKey
+	pending map[coalescingKey]DuckEvent
+	ready   chan struct{}
+}
+
+func newCoalescingQueue() *coalescingQueue {
+	return &coalescingQueue{
+		pending: map[coalescingKey]DuckEvent{},
+		ready:   make(chan struct{}, 1),
+	}
+}
+
+// push an event onto the queue, returns true if the event replaced a pending event
+func (q *coalescingQueue) push(msg DuckEvent) bool {
+	q.m.Lock()
+	defer q.m.Unlock()
+
+	key := coalescingKey{
+		duckType:  msg.DuckType,
+		GroupKind: msg.Object.GetObjectKind().GroupVersionKind().GroupKind(),
+		NamespacedName: types.NamespacedName{
+			Namespace: msg.Object.GetNamespace(),
+			Name:      msg.Object.GetName(),
+		},
+	}
+	if msg.Duck != nil && !msg.IsDuckEvent() {
+		key.GroupKind = schema.GroupKind{Group: msg.Duck.Spec.Group, Kind: msg.Duck.Spec.Kind}
+	}
+
+	prior, coalesced := q.pending[key]
+	if coalesced {
+		if prior.Type == DuckEventCreate && msg.Type == DuckEventUpdate {
+			// the subscriber has not seen the object yet
+			msg.Type = DuckEventCreate
+			msg.OldObject = nil
+		} else if prior.Type == DuckEventUpdate && msg.Type == DuckEventUpdate {
+			// retain the state the subscriber last saw
+			msg.OldObject = prior.OldObject
+		}
+	} else {
+		q.keys = append(q.keys, key)
+	}
+	q.pending[key] = msg
+
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+
+	return coalesced
+}
+
+// pop the next event from the queue, waiting for an event if the queue is empty. Returns false if
+// the context is done.
+func (q *coalescingQueue) pop(ctx context.Context) (DuckEvent, bool) {
+	for {
+		q.m.Lock()
+		if len(q.keys) != 0 {
+			key := q.keys[0]
+			q.keys = q.keys[1:]
+			msg := q.pending[key]
+			delete(q.pending, key)
+			q.m.Unlock()
+			return msg, true
+		}
+		q.m.Unlock()
+
+		select {
+		case <-q.ready:
+		case <-ctx.Done():
+			return DuckEvent{}, false
+		}
+	}
+}
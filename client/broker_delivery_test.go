@@ -0,0 +1,437 @@
+/*
+Copyright 2025 the original author or authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	duckv1 "reconciler.io/ducks/api/v1"
+)
+
+var (
+	testDuckType = schema.GroupKind{Group: "example.com", Kind: "ConditionDuck"}
+	testDuck     = &duckv1.Duck{
+		ObjectMeta: metav1.ObjectMeta{Name: "deployments.apps"},
+		Spec: duckv1.DuckSpec{
+			Group:   "apps",
+			Version: "v1",
+			Kind:    "Deployment",
+		},
+	}
+)
+
+// testObject is a resource implementing the test duck, the generation distinguishes each state of
+// the resource
+func testObject(name string, generation int64) client.Object {
+	return &metav1.PartialObjectMetadata{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, Generation: generation},
+	}
+}
+
+func testEvent(eventType DuckEventType, obj, oldObj client.Object) DuckEvent {
+	return DuckEvent{Type: eventType, Object: obj, OldObject: oldObj, Duck: testDuck, DuckType: testDuckType}
+}
+
+// describeEvent summarizes an event for comparison
+func describeEvent(msg DuckEvent) string {
+	s := fmt.Sprintf("%s %s@%d", msg.Type, msg.Object.GetName(), msg.Object.GetGeneration())
+	if msg.OldObject != nil {
+		s = fmt.Sprintf("%s from %d", s, msg.OldObject.GetGeneration())
+	}
+	return s
+}
+
+func TestCoalescingQueue(t *testing.T) {
+	tests := map[string]struct {
+		pushed            []DuckEvent
+		expectedCoalesced []bool
+		expected          []string
+	}{
+		"distinct objects in order": {
+			pushed: []DuckEvent{
+				testEvent(DuckEventCreate, testObject("blue", 1), nil),
+				testEvent(DuckEventCreate, testObject("green", 1), nil),
+				testEvent(DuckEventDelete, testObject("red", 1), nil),
+			},
+			expectedCoalesced: []bool{false, false, false},
+			expected: []string{
+				"Create blue@1",
+				"Create green@1",
+				"Delete red@1",
+			},
+		},
+		"create then update is a create": {
+			pushed: []DuckEvent{
+				testEvent(DuckEventCreate, testObject("blue", 1), nil),
+				testEvent(DuckEventUpdate, testObject("blue", 2), testObject("blue", 1)),
+			},
+			expectedCoalesced: []bool{false, true},
+			expected: []string{
+				"Create blue@2",
+			},
+		},
+		"update then update keeps the first old object": {
+			pushed: []DuckEvent{
+				testEvent(DuckEventUpdate, testObject("blue", 2), testObject("blue", 1)),
+				testEvent(DuckEventUpdate, testObject("blue", 3), testObject("blue", 2)),
+			},
+			expectedCoalesced: []bool{false, true},
+			expected: []string{
+				"Update blue@3 from 1",
+			},
+		},
+		"update then delete is a delete": {
+			pushed: []DuckEvent{
+				testEvent(DuckEventUpdate, testObject("blue", 2), testObject("blue", 1)),
+				testEvent(DuckEventDelete, testObject("blue", 2), nil),
+			},
+			expectedCoalesced: []bool{false, true},
+			expected: []string{
+				"Delete blue@2",
+			},
+		},
+		"coalesced events keep their position": {
+			pushed: []DuckEvent{
+				testEvent(DuckEventCreate, testObject("blue", 1), nil),
+				testEvent(DuckEventCreate, testObject("green", 1), nil),
+				testEvent(DuckEventUpdate, testObject("blue", 2), testObject("blue", 1)),
+			},
+			expectedCoalesced: []bool{false, false, true},
+			expected: []string{
+				"Create blue@2",
+				"Create green@1",
+			},
+		},
+		"duck types are not coalesced": {
+			pushed: []DuckEvent{
+				testEvent(DuckEventCreate, testObject("blue", 1), nil),
+				{
+					Type:     DuckEventCreate,
+					Object:   testObject("blue", 1),
+					Duck:     testDuck,
+					DuckType: schema.GroupKind{Group: "example.com", Kind: "OtherDuck"},
+				},
+			},
+			expectedCoalesced: []bool{false, false},
+			expected: []string{
+				"Create blue@1",
+				"Create blue@1",
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			queue := newCoalescingQueue()
+			coalesced := []bool{}
+			for _, msg := range tc.pushed {
+				coalesced = append(coalesced, queue.push(msg))
+			}
+			if diff := cmp.Diff(tc.expectedCoalesced, coalesced); diff != "" {
+				t.Errorf("unexpected coalesced (-expected, +actual): %s", diff)
+			}
+
+			actual := []string{}
+			for range tc.expected {
+				msg, ok := queue.pop(ctx)
+				if !ok {
+					t.Fatalf("expected an event")
+				}
+				actual = append(actual, describeEvent(msg))
+			}
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("unexpected events (-expected, +actual): %s", diff)
+			}
+
+			// the queue is drained, pop waits for the context
+			cancel()
+			if msg, ok := queue.pop(ctx); ok {
+				t.Errorf("unexpected event %s", describeEvent(msg))
+			}
+		})
+	}
+}
+
+func TestDeliverTo(t *testing.T) {
+	// seven events overflow the subscriber's buffer of five
+	pushed := []DuckEvent{}
+	for i := range 7 {
+		pushed = append(pushed, testEvent(DuckEventCreate, testObject(fmt.Sprintf("resource-%d", i), 1), nil))
+	}
+	all := []string{}
+	for _, msg := range pushed {
+		all = append(all, describeEvent(msg))
+	}
+
+	tests := map[string]struct {
+		policy   DeliveryPolicy
+		pushed   []DuckEvent
+		expected []string
+	}{
+		"drop newest": {
+			policy:   DeliveryDropNewest,
+			pushed:   pushed,
+			expected: all[:5],
+		},
+		"drop oldest": {
+			policy:   DeliveryDropOldest,
+			pushed:   pushed,
+			expected: all[2:],
+		},
+		"block": {
+			policy:   DeliveryBlock,
+			pushed:   pushed,
+			expected: all,
+		},
+		"coalesce": {
+			policy:   DeliveryCoalesce,
+			pushed:   pushed,
+			expected: all,
+		},
+		"coalesce merges pending events": {
+			policy: DeliveryCoalesce,
+			pushed: slices.Concat(pushed, []DuckEvent{
+				testEvent(DuckEventUpdate, testObject("resource-6", 2), testObject("resource-6", 1)),
+				testEvent(DuckEventUpdate, testObject("resource-6", 3), testObject("resource-6", 2)),
+			}),
+			// five events are buffered and a sixth is held by the sender, the seventh is still
+			// queued when it is updated
+			expected: slices.Concat(all[:6], []string{"Create resource-6@3"}),
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			b := &broker{name: "TestBroker"}
+			sub := b.newSubscriber([]SubscribeOption{SubscribeDeliveryPolicy(tc.policy)})
+			msgCh := deliverTo(ctx, b, sub, func(msg DuckEvent) DuckEvent {
+				return msg
+			})
+
+			// deliver as the broker loop would, without a receiver
+			delivered := make(chan struct{})
+			go func() {
+				defer close(delivered)
+				for _, msg := range tc.pushed {
+					sub.deliver(msg)
+				}
+			}()
+			if tc.policy != DeliveryBlock {
+				select {
+				case <-delivered:
+				case <-time.After(5 * time.Second):
+					t.Fatalf("timed out waiting for delivery")
+				}
+			}
+
+			actual := []string{}
+			for range tc.expected {
+				select {
+				case msg := <-msgCh:
+					actual = append(actual, describeEvent(msg))
+				case <-time.After(5 * time.Second):
+					t.Fatalf("timed out waiting for an event, received %v", actual)
+				}
+			}
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("unexpected events (-expected, +actual): %s", diff)
+			}
+			select {
+			case msg := <-msgCh:
+				t.Errorf("unexpected event %s", describeEvent(msg))
+			case <-time.After(50 * time.Millisecond):
+			}
+		})
+	}
+
+	t.Run("block is released when the subscriber is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		b := &broker{name: "TestBroker"}
+		sub := b.newSubscriber([]SubscribeOption{SubscribeDeliveryPolicy(DeliveryBlock)})
+		deliverTo(ctx, b, sub, func(msg DuckEvent) DuckEvent {
+			return msg
+		})
+
+		delivered := make(chan struct{})
+		go func() {
+			defer close(delivered)
+			for _, msg := range pushed {
+				sub.deliver(msg)
+			}
+		}()
+		cancel()
+		select {
+		case <-delivered:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for delivery to unblock")
+		}
+	})
+}
+
+func TestSubscribeTyped(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	b := &broker{
+		name:      "TestBroker",
+		publishCh: make(chan DuckEvent),
+		// unbuffered so subscriptions are registered before events are published
+		subCh:   make(chan *subscriber),
+		unsubCh: make(chan *subscriber),
+		done:    ctx.Done(),
+	}
+	go b.Start(ctx)
+
+	typed := b.SubscribeTyped(ctx, SubscribeDeliveryPolicy(DeliveryBlock))
+	generic := b.Subscribe(ctx, SubscribeDeliveryPolicy(DeliveryBlock))
+
+	published := []DuckEvent{
+		testEvent(DuckEventCreate, testObject("blue", 1), nil),
+		testEvent(DuckEventUpdate, testObject("blue", 2), testObject("blue", 1)),
+		testEvent(DuckEventDelete, testObject("blue", 2), nil),
+		testEvent(DuckEventDuckAdded, duckObject(testDuck), nil),
+		testEvent(DuckEventDuckRemoved, duckObject(testDuck), nil),
+	}
+	for _, msg := range published {
+		b.Publish(msg)
+
+		select {
+		case actual := <-typed:
+			if actual.Type != msg.Type {
+				t.Errorf("expected %s event, got %s", msg.Type, actual.Type)
+			}
+			if actual.Object != msg.Object || actual.OldObject != msg.OldObject {
+				t.Errorf("expected %s event objects to be preserved", msg.Type)
+			}
+			if actual.Duck != testDuck || actual.DuckType != testDuckType {
+				t.Errorf("expected %s event duck to be preserved", msg.Type)
+			}
+			if actual.IsDuckEvent() != (msg.Type == DuckEventDuckAdded || msg.Type == DuckEventDuckRemoved) {
+				t.Errorf("unexpected IsDuckEvent for %s event", msg.Type)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for typed %s event", msg.Type)
+		}
+
+		select {
+		case actual := <-generic:
+			if diff := cmp.Diff(event.GenericEvent{Object: msg.Object}, actual); diff != "" {
+				t.Errorf("unexpected generic event (-expected, +actual): %s", diff)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for generic %s event", msg.Type)
+		}
+	}
+}
+
+func TestUnsubscribe(t *testing.T) {
+	// series across every broker, tests in the package do not run in parallel
+	series := func() int {
+		return testutil.CollectAndCount(brokerEventsDelivered) +
+			testutil.CollectAndCount(brokerEventsCoalesced) +
+			testutil.CollectAndCount(brokerEventsDropped)
+	}
+	startBroker := func(t *testing.T) (*broker, context.CancelFunc) {
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+
+		b := &broker{
+			name:      "UnsubscribeBroker",
+			publishCh: make(chan DuckEvent),
+			subCh:     make(chan *subscriber),
+			unsubCh:   make(chan *subscriber),
+			done:      ctx.Done(),
+		}
+		go b.Start(ctx)
+		return b, cancel
+	}
+
+	for _, policy := range []DeliveryPolicy{DeliveryDropNewest, DeliveryDropOldest, DeliveryBlock, DeliveryCoalesce} {
+		t.Run(fmt.Sprintf("deletes %s subscriber metrics", policy), func(t *testing.T) {
+			b, _ := startBroker(t)
+			existing := series()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			events := b.SubscribeTyped(ctx, SubscribeDeliveryPolicy(policy))
+			b.Publish(testEvent(DuckEventCreate, testObject("blue", 1), nil))
+			select {
+			case <-events:
+			case <-time.After(5 * time.Second):
+				t.Fatalf("timed out waiting for an event")
+			}
+			if actual := series() - existing; actual != 3 {
+				t.Fatalf("expected 3 metric series for the subscriber, got %d", actual)
+			}
+
+			cancel()
+			waitForCondition(t, func() bool {
+				return series() == existing
+			})
+		})
+	}
+
+	t.Run("unsubscribes from a stopped broker", func(t *testing.T) {
+		b, stop := startBroker(t)
+		existing := series()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		b.SubscribeTyped(ctx)
+		stop()
+		cancel()
+		waitForCondition(t, func() bool {
+			return series() == existing
+		})
+	})
+
+	t.Run("subscribes to a stopped broker", func(t *testing.T) {
+		b, stop := startBroker(t)
+		stop()
+		existing := series()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		subscribed := make(chan struct{})
+		go func() {
+			defer close(subscribed)
+			b.SubscribeTyped(ctx)
+		}()
+		select {
+		case <-subscribed:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out subscribing to a stopped broker")
+		}
+		cancel()
+		waitForCondition(t, func() bool {
+			return series() == existing
+		})
+	})
+}
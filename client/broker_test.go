@@ -0,0 +1,868 @@
+/*
+Copyright 2025 the original author or authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/rest"
+	toolscache "k8s.io/client-go/tools/cache"
+	"reconciler.io/runtime/apis"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllertest"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	duckv1 "reconciler.io/ducks/api/v1"
+)
+
+func TestSubscribeFilters(t *testing.T) {
+	duckType := schema.GroupKind{Group: "example.com", Kind: "ConditionDuck"}
+	duck := &duckv1.Duck{
+		ObjectMeta: metav1.ObjectMeta{Name: "deployments.apps"},
+		Spec: duckv1.DuckSpec{
+			Group:   "apps",
+			Version: "v1",
+			Kind:    "Deployment",
+		},
+	}
+	resource := func(namespace string, labels map[string]string) client.Object {
+		return &metav1.PartialObjectMetadata{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "my-resource", Labels: labels},
+		}
+	}
+	duckEvent := func(eventType DuckEventType, obj client.Object) DuckEvent {
+		return DuckEvent{Type: eventType, Object: obj, Duck: duck, DuckType: duckType}
+	}
+
+	tests := map[string]struct {
+		opts     []SubscribeOption
+		msg      DuckEvent
+		expected bool
+	}{
+		"no filters": {
+			msg:      duckEvent(DuckEventCreate, resource("default", nil)),
+			expected: true,
+		},
+		"namespace matches": {
+			opts:     []SubscribeOption{SubscribeNamespace("default")},
+			msg:      duckEvent(DuckEventCreate, resource("default", nil)),
+			expected: true,
+		},
+		"namespace does not match": {
+			opts:     []SubscribeOption{SubscribeNamespace("default")},
+			msg:      duckEvent(DuckEventCreate, resource("other", nil)),
+			expected: false,
+		},
+		"namespace allows duck added": {
+			opts:     []SubscribeOption{SubscribeNamespace("default")},
+			msg:      duckEvent(DuckEventDuckAdded, duckObject(duck)),
+			expected: true,
+		},
+		"namespace allows duck removed": {
+			opts:     []SubscribeOption{SubscribeNamespace("default")},
+			msg:      duckEvent(DuckEventDuckRemoved, duckObject(duck)),
+			expected: true,
+		},
+		"label selector matches": {
+			opts:     []SubscribeOption{SubscribeLabelSelector(labels.SelectorFromSet(labels.Set{"app": "blue"}))},
+			msg:      duckEvent(DuckEventCreate, resource("default", map[string]string{"app": "blue"})),
+			expected: true,
+		},
+		"label selector does not match": {
+			opts:     []SubscribeOption{SubscribeLabelSelector(labels.SelectorFromSet(labels.Set{"app": "blue"}))},
+			msg:      duckEvent(DuckEventCreate, resource("default", map[string]string{"app": "green"})),
+			expected: false,
+		},
+		"label selector matches old object": {
+			opts: []SubscribeOption{SubscribeLabelSelector(labels.SelectorFromSet(labels.Set{"app": "blue"}))},
+			msg: DuckEvent{
+				Type:      DuckEventUpdate,
+				Object:    resource("default", map[string]string{"app": "green"}),
+				OldObject: resource("default", map[string]string{"app": "blue"}),
+				Duck:      duck,
+				DuckType:  duckType,
+			},
+			expected: true,
+		},
+		"label selector allows duck added": {
+			opts:     []SubscribeOption{SubscribeLabelSelector(labels.SelectorFromSet(labels.Set{"app": "blue"}))},
+			msg:      duckEvent(DuckEventDuckAdded, duckObject(duck)),
+			expected: true,
+		},
+		"duck type matches": {
+			opts:     []SubscribeOption{SubscribeDuckType(duckType)},
+			msg:      duckEvent(DuckEventCreate, resource("default", nil)),
+			expected: true,
+		},
+		"duck type does not match": {
+			opts:     []SubscribeOption{SubscribeDuckType(schema.GroupKind{Group: "example.com", Kind: "OtherDuck"})},
+			msg:      duckEvent(DuckEventCreate, resource("default", nil)),
+			expected: false,
+		},
+		"group kind matches": {
+			opts:     []SubscribeOption{SubscribeGroupKind(schema.GroupKind{Group: "apps", Kind: "Deployment"})},
+			msg:      duckEvent(DuckEventCreate, resource("default", nil)),
+			expected: true,
+		},
+		"group kind does not match": {
+			opts:     []SubscribeOption{SubscribeGroupKind(schema.GroupKind{Group: "batch", Kind: "Job"})},
+			msg:      duckEvent(DuckEventCreate, resource("default", nil)),
+			expected: false,
+		},
+		"predicates evaluated by operation": {
+			opts: []SubscribeOption{SubscribePredicates(predicate.Funcs{
+				CreateFunc: func(event.CreateEvent) bool { return true },
+				DeleteFunc: func(event.DeleteEvent) bool { return false },
+			})},
+			msg:      duckEvent(DuckEventDelete, resource("default", nil)),
+			expected: false,
+		},
+		"predicates reject": {
+			opts: []SubscribeOption{SubscribePredicates(predicate.Funcs{
+				CreateFunc: func(event.CreateEvent) bool { return false },
+			})},
+			msg:      duckEvent(DuckEventCreate, resource("default", nil)),
+			expected: false,
+		},
+		"every filter must accept": {
+			opts: []SubscribeOption{
+				SubscribeNamespace("default"),
+				SubscribeGroupKind(schema.GroupKind{Group: "batch", Kind: "Job"}),
+			},
+			msg:      duckEvent(DuckEventCreate, resource("default", nil)),
+			expected: false,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			b := &broker{}
+			sub := b.newSubscriber(tc.opts)
+			if actual := sub.accepts(tc.msg); actual != tc.expected {
+				t.Errorf("expected accepts to be %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestNewBrokerRequiresCacheOptions(t *testing.T) {
+	if _, err := NewBroker(&brokerManager{}, testDuckType); !errors.Is(err, ErrCacheOptionsRequired) {
+		t.Errorf("expected ErrCacheOptionsRequired, got %v", err)
+	}
+}
+
+func TestNewBrokerForDuckTypes(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(duckv1.AddToScheme(scheme))
+
+	duckType := func(name, group, kind string, ready metav1.ConditionStatus) *duckv1.DuckType {
+		return &duckv1.DuckType{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: duckv1.DuckTypeSpec{
+				Group:  group,
+				Plural: strings.ToLower(kind) + "s",
+				Kind:   kind,
+			},
+			Status: duckv1.DuckTypeStatus{
+				Status: apis.Status{
+					Conditions: []metav1.Condition{
+						{Type: duckv1.DuckTypeConditionReady, Status: ready},
+					},
+				},
+			},
+		}
+	}
+
+	tests := map[string]struct {
+		given             []client.Object
+		duckTypes         []string
+		expectedDuckTypes []schema.GroupKind
+		expectedErr       error
+	}{
+		"derives the group and kind from the spec": {
+			given: []client.Object{
+				duckType("conditions.example.com", "example.com", "ConditionDuck", metav1.ConditionTrue),
+				duckType("bindings.example.org", "duck.example.org", "BindingDuck", metav1.ConditionTrue),
+			},
+			duckTypes: []string{"conditions.example.com", "bindings.example.org"},
+			expectedDuckTypes: []schema.GroupKind{
+				{Group: "example.com", Kind: "ConditionDuck"},
+				{Group: "duck.example.org", Kind: "BindingDuck"},
+			},
+		},
+		"missing duck type": {
+			given: []client.Object{
+				duckType("conditions.example.com", "example.com", "ConditionDuck", metav1.ConditionTrue),
+			},
+			duckTypes:   []string{"conditions.example.com", "bindings.example.org"},
+			expectedErr: ErrUnknownDuckType,
+		},
+		"duck type not ready": {
+			given: []client.Object{
+				duckType("conditions.example.com", "example.com", "ConditionDuck", metav1.ConditionTrue),
+				duckType("bindings.example.org", "duck.example.org", "BindingDuck", metav1.ConditionFalse),
+			},
+			duckTypes:   []string{"conditions.example.com", "bindings.example.org"},
+			expectedErr: ErrDuckTypeNotReady,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			mgr := &brokerManager{
+				apiReader: fake.NewClientBuilder().WithScheme(scheme).WithObjects(tc.given...).Build(),
+				scheme:    scheme,
+			}
+			actual, err := NewBrokerForDuckTypes(t.Context(), mgr, tc.duckTypes, WithCacheOptions(cache.Options{}))
+			if tc.expectedErr != nil {
+				if !errors.Is(err, tc.expectedErr) {
+					t.Fatalf("expected err %q, got %v", tc.expectedErr, err)
+				}
+				if !strings.Contains(err.Error(), "bindings.example.org") {
+					t.Errorf("expected err to name the duck type, got %q", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected err: %s", err)
+			}
+			b := actual.(*broker)
+			t.Cleanup(b.cancel)
+			if diff := cmp.Diff(tc.expectedDuckTypes, b.duckTypes); diff != "" {
+				t.Errorf("unexpected duck types (-expected, +actual): %s", diff)
+			}
+			if expected := "ConditionDuckBindingDuckBroker"; b.name != expected {
+				t.Errorf("expected broker name %q, got %q", expected, b.name)
+			}
+		})
+	}
+}
+
+func TestBrokerPublishesDuckEventsWithoutLock(t *testing.T) {
+	b, duckTypeInformer, _ := startTestBroker(t)
+
+	// nothing receives from the broker loop, publishing blocks
+	added := make(chan struct{})
+	go func() {
+		defer close(added)
+		duckTypeInformer.Add(readyTestDuck())
+	}()
+	waitForCondition(t, func() bool {
+		// the lock must not be held while publishing
+		if !b.m.TryLock() {
+			return false
+		}
+		defer b.m.Unlock()
+		return len(b.duckInformers) == 1
+	})
+
+	synced := make(chan bool)
+	go func() {
+		synced <- b.Synced()
+	}()
+	select {
+	case <-synced:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for Synced while a duck event is published")
+	}
+
+	select {
+	case msg := <-b.publishCh:
+		if msg.Type != DuckEventDuckAdded {
+			t.Errorf("expected DuckAdded event, got %s", msg.Type)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for DuckAdded event")
+	}
+	<-added
+}
+
+func TestBrokerSynced(t *testing.T) {
+	deploymentGVK := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+
+	t.Run("waits for existing ducks to be handled", func(t *testing.T) {
+		b, duckTypeInformer, _ := startTestBroker(t)
+		go b.Start(t.Context())
+
+		// the informer has synced, the handler has not seen the existing ducks
+		if b.Synced() {
+			t.Errorf("expected broker not to be synced")
+		}
+
+		duckTypeInformer.Add(readyTestDuck())
+		duckTypeInformer.handled.Store(true)
+		if !b.Synced() {
+			t.Errorf("expected broker to be synced")
+		}
+	})
+
+	t.Run("waits for duck informers", func(t *testing.T) {
+		b, duckTypeInformer, _ := startTestBroker(t)
+		go b.Start(t.Context())
+		deploymentInformer := controllertest.NewFakeInformer()
+		b.cache.(*recordingCache).InformersByGVK = map[schema.GroupVersionKind]toolscache.SharedIndexInformer{
+			deploymentGVK: deploymentInformer,
+		}
+
+		duckTypeInformer.Add(readyTestDuck())
+		duckTypeInformer.handled.Store(true)
+		if b.Synced() {
+			t.Errorf("expected broker not to be synced")
+		}
+
+		deploymentInformer.Synced()
+		if !b.Synced() {
+			t.Errorf("expected broker to be synced")
+		}
+	})
+
+	t.Run("checker and wait for sync", func(t *testing.T) {
+		b, duckTypeInformer, _ := startTestBroker(t)
+
+		if err := b.Checker(nil); err == nil {
+			t.Errorf("expected checker to fail")
+		}
+		ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+		defer cancel()
+		if err := b.WaitForSync(ctx); err == nil {
+			t.Errorf("expected wait for sync to time out")
+		}
+
+		duckTypeInformer.handled.Store(true)
+		if err := b.Checker(nil); err != nil {
+			t.Errorf("unexpected checker err: %s", err)
+		}
+		if err := b.WaitForSync(t.Context()); err != nil {
+			t.Errorf("unexpected wait for sync err: %s", err)
+		}
+	})
+}
+
+func TestBrokerInformerLifecycle(t *testing.T) {
+	deploymentGVK := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+	otherDuckType := schema.GroupKind{Group: "example.com", Kind: "OtherDuck"}
+	expectInformers := func(t *testing.T, b *broker, expected map[duckInformerKey]schema.GroupVersionKind, expectedRefs map[informerKey]int) {
+		t.Helper()
+
+		b.m.Lock()
+		defer b.m.Unlock()
+
+		actual := map[duckInformerKey]schema.GroupVersionKind{}
+		for key, di := range b.duckInformers {
+			actual[key] = di.gvk
+		}
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			t.Errorf("unexpected duck informers (-expected, +actual): %s", diff)
+		}
+		if diff := cmp.Diff(expectedRefs, b.informerRefs, cmp.AllowUnexported(informerKey{})); diff != "" {
+			t.Errorf("unexpected informer refs (-expected, +actual): %s", diff)
+		}
+	}
+	deploymentKey := informerKey{gvk: deploymentGVK, objType: "*unstructured.Unstructured"}
+
+	t.Run("removes the informer when a duck is deleted", func(t *testing.T) {
+		b, duckTypeInformer, _ := startTestBroker(t)
+		events := collectEvents(t, b)
+
+		duckTypeInformer.Add(readyTestDuck())
+		expectDuckEvent(t, events, DuckEventDuckAdded, testDuckType)
+		expectInformers(t, b, map[duckInformerKey]schema.GroupVersionKind{
+			{duckType: testDuckType, name: testDuck.Name}: deploymentGVK,
+		}, map[informerKey]int{deploymentKey: 1})
+
+		duckTypeInformer.Delete(readyTestDuck())
+		expectDuckEvent(t, events, DuckEventDuckRemoved, testDuckType)
+		expectInformers(t, b, map[duckInformerKey]schema.GroupVersionKind{}, map[informerKey]int{})
+		if diff := cmp.Diff([]schema.GroupVersionKind{deploymentGVK}, b.cache.(*recordingCache).removals()); diff != "" {
+			t.Errorf("unexpected removed informers (-expected, +actual): %s", diff)
+		}
+	})
+
+	t.Run("removes the informer when a duck is no longer ready", func(t *testing.T) {
+		b, duckTypeInformer, _ := startTestBroker(t)
+		events := collectEvents(t, b)
+
+		duckTypeInformer.Add(readyTestDuck())
+		expectDuckEvent(t, events, DuckEventDuckAdded, testDuckType)
+
+		notReady := duckObject(testDuck).(*unstructured.Unstructured)
+		duckTypeInformer.Update(readyTestDuck(), notReady)
+		expectDuckEvent(t, events, DuckEventDuckRemoved, testDuckType)
+		expectInformers(t, b, map[duckInformerKey]schema.GroupVersionKind{}, map[informerKey]int{})
+		if diff := cmp.Diff([]schema.GroupVersionKind{deploymentGVK}, b.cache.(*recordingCache).removals()); diff != "" {
+			t.Errorf("unexpected removed informers (-expected, +actual): %s", diff)
+		}
+	})
+
+	t.Run("keeps an informer used by another duck type", func(t *testing.T) {
+		b, duckTypeInformers, _ := startTestMultiBroker(t, []schema.GroupKind{testDuckType, otherDuckType})
+		events := collectEvents(t, b)
+
+		duckTypeInformers[testDuckType].Add(readyTestDuck())
+		expectDuckEvent(t, events, DuckEventDuckAdded, testDuckType)
+		duckTypeInformers[otherDuckType].Add(readyTestDuck())
+		expectDuckEvent(t, events, DuckEventDuckAdded, otherDuckType)
+		expectInformers(t, b, map[duckInformerKey]schema.GroupVersionKind{
+			{duckType: testDuckType, name: testDuck.Name}:  deploymentGVK,
+			{duckType: otherDuckType, name: testDuck.Name}: deploymentGVK,
+		}, map[informerKey]int{deploymentKey: 2})
+
+		duckTypeInformers[testDuckType].Delete(readyTestDuck())
+		expectDuckEvent(t, events, DuckEventDuckRemoved, testDuckType)
+		expectInformers(t, b, map[duckInformerKey]schema.GroupVersionKind{
+			{duckType: otherDuckType, name: testDuck.Name}: deploymentGVK,
+		}, map[informerKey]int{deploymentKey: 1})
+		if removed := b.cache.(*recordingCache).removals(); len(removed) != 0 {
+			t.Errorf("expected the shared informer to be kept, removed %v", removed)
+		}
+
+		duckTypeInformers[otherDuckType].Delete(readyTestDuck())
+		expectDuckEvent(t, events, DuckEventDuckRemoved, otherDuckType)
+		expectInformers(t, b, map[duckInformerKey]schema.GroupVersionKind{}, map[informerKey]int{})
+		if diff := cmp.Diff([]schema.GroupVersionKind{deploymentGVK}, b.cache.(*recordingCache).removals()); diff != "" {
+			t.Errorf("unexpected removed informers (-expected, +actual): %s", diff)
+		}
+	})
+
+	t.Run("restarts the informer when the duck's version changes", func(t *testing.T) {
+		b, duckTypeInformer, _ := startTestBroker(t)
+		events := collectEvents(t, b)
+
+		duckTypeInformer.Add(readyTestDuck())
+		expectDuckEvent(t, events, DuckEventDuckAdded, testDuckType)
+
+		v1beta2 := readyTestDuck()
+		if err := unstructured.SetNestedField(v1beta2.Object, "v1beta2", "spec", "version"); err != nil {
+			t.Fatalf("unexpected err: %s", err)
+		}
+		duckTypeInformer.Update(readyTestDuck(), v1beta2)
+		v1beta2GVK := schema.GroupVersionKind{Group: "apps", Version: "v1beta2", Kind: "Deployment"}
+		expectInformers(t, b, map[duckInformerKey]schema.GroupVersionKind{
+			{duckType: testDuckType, name: testDuck.Name}: v1beta2GVK,
+		}, map[informerKey]int{{gvk: v1beta2GVK, objType: "*unstructured.Unstructured"}: 1})
+		if diff := cmp.Diff([]schema.GroupVersionKind{deploymentGVK}, b.cache.(*recordingCache).removals()); diff != "" {
+			t.Errorf("unexpected removed informers (-expected, +actual): %s", diff)
+		}
+
+		// the duck is unchanged for subscribers
+		select {
+		case msg := <-events:
+			t.Errorf("unexpected %s event", msg.Type)
+		case <-time.After(50 * time.Millisecond):
+		}
+	})
+}
+
+func TestBrokerSharesInformersBetweenDuckTypes(t *testing.T) {
+	otherDuckType := schema.GroupKind{Group: "example.com", Kind: "OtherDuck"}
+	b, duckTypeInformers, _ := startTestMultiBroker(t, []schema.GroupKind{testDuckType, otherDuckType})
+	go b.Start(t.Context())
+
+	all := b.SubscribeTyped(t.Context(), SubscribeDeliveryPolicy(DeliveryBlock))
+	filtered := b.SubscribeTyped(t.Context(), SubscribeDeliveryPolicy(DeliveryBlock), SubscribeDuckType(otherDuckType))
+	receive := func(t *testing.T, events <-chan DuckEvent, count int) []string {
+		t.Helper()
+
+		received := []string{}
+		for range count {
+			select {
+			case msg := <-events:
+				received = append(received, fmt.Sprintf("%s %s", msg.DuckType.Kind, msg.Type))
+			case <-time.After(5 * time.Second):
+				t.Fatalf("timed out waiting for an event, received %v", received)
+			}
+		}
+		select {
+		case msg := <-events:
+			t.Errorf("unexpected %s event for %s", msg.Type, msg.DuckType)
+		case <-time.After(50 * time.Millisecond):
+		}
+		slices.Sort(received)
+		return received
+	}
+
+	duckTypeInformers[testDuckType].Add(readyTestDuck())
+	duckTypeInformers[otherDuckType].Add(readyTestDuck())
+	if diff := cmp.Diff([]string{"ConditionDuck DuckAdded", "OtherDuck DuckAdded"}, receive(t, all, 2)); diff != "" {
+		t.Errorf("unexpected events (-expected, +actual): %s", diff)
+	}
+	if diff := cmp.Diff([]string{"OtherDuck DuckAdded"}, receive(t, filtered, 1)); diff != "" {
+		t.Errorf("unexpected filtered events (-expected, +actual): %s", diff)
+	}
+
+	b.m.Lock()
+	informer := b.duckInformers[duckInformerKey{duckType: testDuckType, name: testDuck.Name}].informer
+	other := b.duckInformers[duckInformerKey{duckType: otherDuckType, name: testDuck.Name}].informer
+	b.m.Unlock()
+	if informer != other {
+		t.Fatalf("expected the duck types to share an informer")
+	}
+
+	informer.(*controllertest.FakeInformer).Add(testObject("blue", 1))
+	if diff := cmp.Diff([]string{"ConditionDuck Create", "OtherDuck Create"}, receive(t, all, 2)); diff != "" {
+		t.Errorf("unexpected events (-expected, +actual): %s", diff)
+	}
+	if diff := cmp.Diff([]string{"OtherDuck Create"}, receive(t, filtered, 1)); diff != "" {
+		t.Errorf("unexpected filtered events (-expected, +actual): %s", diff)
+	}
+}
+
+func expectDuckEvent(t *testing.T, events <-chan DuckEvent, eventType DuckEventType, duckType schema.GroupKind) {
+	t.Helper()
+
+	select {
+	case msg := <-events:
+		if msg.Type != eventType || msg.DuckType != duckType {
+			t.Errorf("expected %s event for %s, got %s event for %s", eventType, duckType, msg.Type, msg.DuckType)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for %s event for %s", eventType, duckType)
+	}
+}
+
+func TestBrokerSkipsMalformedDucks(t *testing.T) {
+	b, duckTypeInformer, _ := startTestBroker(t)
+	events := collectEvents(t, b)
+	errs := testutil.ToFloat64(brokerDuckErrors.WithLabelValues(b.name))
+
+	malformed := readyTestDuck()
+	if err := unstructured.SetNestedField(malformed.Object, "not an object", "spec"); err != nil {
+		t.Fatalf("unexpected err: %s", err)
+	}
+	malformed.SetName("malformed")
+	duckTypeInformer.Add(malformed)
+	// not unstructured
+	duckTypeInformer.Add(&metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Name: "unexpected"}})
+
+	if actual := testutil.ToFloat64(brokerDuckErrors.WithLabelValues(b.name)) - errs; actual != 2 {
+		t.Errorf("expected two duck errors, got %v", actual)
+	}
+	b.m.Lock()
+	informers := len(b.duckInformers)
+	started := len(b.cache.(*recordingCache).InformersByGVK)
+	b.m.Unlock()
+	if informers != 0 {
+		t.Errorf("expected no duck informers, got %d", informers)
+	}
+	if started != 0 {
+		t.Errorf("expected no informers to be started, got %d", started)
+	}
+
+	// later ducks are still handled
+	duckTypeInformer.Add(readyTestDuck())
+	expectDuckEvent(t, events, DuckEventDuckAdded, testDuckType)
+}
+
+func TestBrokerSchemaChanges(t *testing.T) {
+	deploymentGVK := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+	duckTypeObject := func(group, kind string, s *apiextensionsv1.JSONSchemaProps) *unstructured.Unstructured {
+		u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&duckv1.DuckType{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: duckv1.GroupVersion.String(),
+				Kind:       "DuckType",
+			},
+			ObjectMeta: metav1.ObjectMeta{Name: "my-duck-type"},
+			Spec: duckv1.DuckTypeSpec{
+				Group:  group,
+				Kind:   kind,
+				Schema: s,
+			},
+		})
+		if err != nil {
+			t.Fatalf("unexpected err: %s", err)
+		}
+		return &unstructured.Unstructured{Object: u}
+	}
+	statusSchema := func(field string) *apiextensionsv1.JSONSchemaProps {
+		return &apiextensionsv1.JSONSchemaProps{
+			Type: "object",
+			Properties: map[string]apiextensionsv1.JSONSchemaProps{
+				"status": {
+					Type: "object",
+					Properties: map[string]apiextensionsv1.JSONSchemaProps{
+						field: {Type: "string"},
+					},
+				},
+			},
+		}
+	}
+	expectSchema := func(t *testing.T, b *broker, expected *apiextensionsv1.JSONSchemaProps) {
+		t.Helper()
+
+		b.schemaM.RLock()
+		actual, ok := b.schemas[deploymentGVK][testDuckType]
+		b.schemaM.RUnlock()
+		if !ok {
+			t.Fatalf("expected a schema for %s", deploymentGVK)
+		}
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			t.Errorf("unexpected schema (-expected, +actual): %s", diff)
+		}
+	}
+
+	b, duckTypeInformer, schemaInformer := startTestBroker(t, WithPruneToDuckType())
+	events := collectEvents(t, b)
+
+	// objects are not pruned until the schema is known
+	duckTypeInformer.Add(readyTestDuck())
+	expectSchema(t, b, nil)
+	if msg := <-events; msg.Type != DuckEventDuckAdded {
+		t.Errorf("expected DuckAdded event, got %s", msg.Type)
+	}
+
+	schemaInformer.Add(duckTypeObject(testDuckType.Group, testDuckType.Kind, statusSchema("ready")))
+	expectSchema(t, b, statusSchema("ready"))
+
+	schemaInformer.Update(
+		duckTypeObject(testDuckType.Group, testDuckType.Kind, statusSchema("ready")),
+		duckTypeObject(testDuckType.Group, testDuckType.Kind, statusSchema("binding")),
+	)
+	expectSchema(t, b, statusSchema("binding"))
+
+	// other duck types are ignored
+	schemaInformer.Add(duckTypeObject(testDuckType.Group, "OtherDuck", statusSchema("other")))
+	expectSchema(t, b, statusSchema("binding"))
+
+	// restarting the duck informer is not a new duck
+	select {
+	case msg := <-events:
+		t.Errorf("unexpected %s event", msg.Type)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+// startTestBroker starts informing on the test duck type, the broker loop is not started. Returns
+// the informers for the duck type's ducks and for DuckTypes.
+func startTestBroker(t *testing.T, opts ...BrokerOption) (*broker, *handlerInformer, *handlerInformer) {
+	t.Helper()
+
+	b, duckTypeInformers, schemaInformer := startTestMultiBroker(t, []schema.GroupKind{testDuckType}, opts...)
+	return b, duckTypeInformers[testDuckType], schemaInformer
+}
+
+// startTestMultiBroker starts informing on each duck type, the broker loop is not started. Returns
+// the informers for each duck type's ducks and for DuckTypes. The broker's cache is a
+// recordingCache.
+func startTestMultiBroker(t *testing.T, duckTypes []schema.GroupKind, opts ...BrokerOption) (*broker, map[schema.GroupKind]*handlerInformer, *handlerInformer) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	scheme := runtime.NewScheme()
+	duckTypeInformers := map[schema.GroupKind]*handlerInformer{}
+	schemaInformer := newHandlerInformer()
+	informers := map[schema.GroupVersionKind]toolscache.SharedIndexInformer{
+		duckv1.GroupVersion.WithKind("DuckType"): schemaInformer,
+	}
+	for _, duckType := range duckTypes {
+		duckTypeInformers[duckType] = newHandlerInformer()
+		informers[duckType.WithVersion("v1")] = duckTypeInformers[duckType]
+	}
+	mgr := &brokerManager{
+		cache: &informertest.FakeInformers{
+			Scheme:         scheme,
+			InformersByGVK: informers,
+		},
+	}
+	b := &broker{
+		name:                  "TestBroker",
+		publishCh:             make(chan DuckEvent),
+		subCh:                 make(chan *subscriber),
+		unsubCh:               make(chan *subscriber),
+		done:                  ctx.Done(),
+		cancel:                cancel,
+		cache:                 &recordingCache{FakeInformers: &informertest.FakeInformers{Scheme: scheme}},
+		schemas:               map[schema.GroupVersionKind]map[schema.GroupKind]*apiextensionsv1.JSONSchemaProps{},
+		duckTypes:             duckTypes,
+		duckTypeRegistrations: map[schema.GroupKind]toolscache.ResourceEventHandlerRegistration{},
+		duckInformers:         map[duckInformerKey]*duckInformer{},
+		informerRefs:          map[informerKey]int{},
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	for _, duckType := range duckTypes {
+		go func() {
+			_ = b.informOnDuckType(mgr, duckType).Start(ctx)
+		}()
+
+		waitForHandler := duckTypeInformers[duckType].registered
+		if b.prune {
+			waitForHandler = schemaInformer.registered
+		}
+		select {
+		case <-waitForHandler:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for the duck type event handler")
+		}
+	}
+
+	return b, duckTypeInformers, schemaInformer
+}
+
+// collectEvents receives the events published by the broker in place of the broker loop
+func collectEvents(t *testing.T, b *broker) <-chan DuckEvent {
+	events := make(chan DuckEvent, 10)
+	go func() {
+		for {
+			select {
+			case msg := <-b.publishCh:
+				events <- msg
+			case <-t.Context().Done():
+				return
+			}
+		}
+	}()
+	return events
+}
+
+func readyTestDuck() *unstructured.Unstructured {
+	duck := testDuck.DeepCopy()
+	duck.Status.Conditions = []metav1.Condition{
+		{Type: duckv1.DuckConditionReady, Status: metav1.ConditionTrue},
+	}
+	return duckObject(duck).(*unstructured.Unstructured)
+}
+
+func waitForCondition(t *testing.T, condition func() bool) {
+	t.Helper()
+
+	timeout := time.After(5 * time.Second)
+	for !condition() {
+		select {
+		case <-time.After(10 * time.Millisecond):
+		case <-timeout:
+			t.Fatalf("timed out waiting for condition")
+		}
+	}
+}
+
+// recordingCache records the informers removed from the cache
+type recordingCache struct {
+	*informertest.FakeInformers
+
+	m       sync.Mutex
+	removed []schema.GroupVersionKind
+}
+
+func (c *recordingCache) RemoveInformer(ctx context.Context, obj client.Object) error {
+	c.m.Lock()
+	c.removed = append(c.removed, obj.GetObjectKind().GroupVersionKind())
+	c.m.Unlock()
+
+	return c.FakeInformers.RemoveInformer(ctx, obj)
+}
+
+func (c *recordingCache) removals() []schema.GroupVersionKind {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	return slices.Clone(c.removed)
+}
+
+type brokerManager struct {
+	manager.Manager
+	cache     cache.Cache
+	apiReader client.Reader
+	scheme    *runtime.Scheme
+}
+
+func (m *brokerManager) GetCache() cache.Cache {
+	return m.cache
+}
+
+func (m *brokerManager) GetAPIReader() client.Reader {
+	return m.apiReader
+}
+
+func (m *brokerManager) GetScheme() *runtime.Scheme {
+	return m.scheme
+}
+
+func (m *brokerManager) GetConfig() *rest.Config {
+	// the broker's cache is not started, the API server is never contacted
+	return &rest.Config{Host: "http://127.0.0.1:1"}
+}
+
+func (m *brokerManager) GetHTTPClient() *http.Client {
+	return http.DefaultClient
+}
+
+func (m *brokerManager) GetRESTMapper() meta.RESTMapper {
+	return meta.NewDefaultRESTMapper(nil)
+}
+
+func (m *brokerManager) Add(manager.Runnable) error {
+	// runnables are not started
+	return nil
+}
+
+// handlerInformer signals when an event handler is registered. The registration syncs once the
+// existing objects are marked as handled.
+type handlerInformer struct {
+	*controllertest.FakeInformer
+	once       sync.Once
+	registered chan struct{}
+	handled    atomic.Bool
+}
+
+func newHandlerInformer() *handlerInformer {
+	return &handlerInformer{
+		FakeInformer: controllertest.NewFakeInformer(controllertest.Synced),
+		registered:   make(chan struct{}),
+	}
+}
+
+func (i *handlerInformer) AddEventHandler(handler toolscache.ResourceEventHandler) (toolscache.ResourceEventHandlerRegistration, error) {
+	registration, err := i.FakeInformer.AddEventHandler(handler)
+	i.once.Do(func() {
+		close(i.registered)
+	})
+	return &handlerRegistration{ResourceEventHandlerRegistration: registration, handled: &i.handled}, err
+}
+
+type handlerRegistration struct {
+	toolscache.ResourceEventHandlerRegistration
+	handled *atomic.Bool
+}
+
+func (r *handlerRegistration) HasSynced() bool {
+	return r.handled.Load()
+}
@@ -18,16 +18,25 @@ package client
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/go-logr/logr"
+	"golang.org/x/sync/errgroup"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"reconciler.io/runtime/apis"
 	"reconciler.io/runtime/duck"
 	"reconciler.io/runtime/reconcilers"
@@ -42,29 +51,86 @@ type Client interface {
 	TrackAndList(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error
 }
 
-func New(duckType string, client reconcilers.Config) Client {
-	return &duckClient{
-		client: client,
+// New creates a client for resources implementing the named duck type. Watch uses the watcher from
+// WithWatcher, falling back to the config's client when it implements client.WithWatch. Paginated
+// lists are read from the config's APIReader, when set, as the controller-runtime cache does not
+// support continue tokens.
+func New(duckType string, config reconcilers.Config, opts ...Option) Client {
+	watcher, _ := config.Client.(client.WithWatch)
+	pager := config
+	if config.APIReader != nil {
+		pager.Client = &apiReaderClient{Client: config.Client, reader: config.APIReader}
+	}
+	c := &duckClient{
+		client:      config,
+		pager:       pager,
+		watcher:     watcher,
+		concurrency: DefaultListConcurrency,
 		duckType: &duckv1.DuckType{
 			ObjectMeta: metav1.ObjectMeta{
 				Name: duckType,
 			},
 		},
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// DefaultListConcurrency is the number of ducks listed in parallel when aggregating a list
+const DefaultListConcurrency = 10
+
+// Option customizes the duck client
+type Option func(*duckClient)
+
+// WithIndex resolves the DuckType and its ducks from the index rather than the API. Tracked
+// lookups continue to use the config so that the reconciled resource is re-enqueued when ducks
+// change. The index is ignored if it is for a different duck type, or has not synced.
+func WithIndex(index *DuckIndex) Option {
+	return func(c *duckClient) {
+		c.index = index
+	}
+}
+
+// WithWatcher watches ducks and their implementers with the watcher. The manager's client does not
+// support watches, a watcher can be created from the manager's config with client.NewWithWatch.
+func WithWatcher(watcher client.WithWatch) Option {
+	return func(c *duckClient) {
+		c.watcher = watcher
+	}
+}
+
+// WithListConcurrency bounds the number of ducks listed in parallel by List and TrackAndList. A
+// limit less than one is unbounded.
+func WithListConcurrency(limit int) Option {
+	return func(c *duckClient) {
+		if limit < 1 {
+			limit = -1
+		}
+		c.concurrency = limit
+	}
 }
 
 var (
-	ErrUnknownDuckType  = errors.New("unknown duck type")
-	ErrUnknownDuck      = errors.New("unknown duck")
-	ErrDuckTypeNotReady = errors.New("duck type is not ready")
+	ErrUnknownDuckType      = errors.New("unknown duck type")
+	ErrUnknownDuck          = errors.New("unknown duck")
+	ErrDuckTypeNotReady     = errors.New("duck type is not ready")
+	ErrWatchNotSupported    = errors.New("watch is not supported by the underlying client")
+	ErrInvalidContinue      = errors.New("invalid continue token")
+	ErrCacheOptionsRequired = errors.New("broker cache options are required")
 )
 
 type duckClient struct {
-	client   Client
-	duckType *duckv1.DuckType
+	client      Client
+	pager       Client
+	watcher     client.WithWatch
+	index       *DuckIndex
+	concurrency int
+	duckType    *duckv1.DuckType
 }
 
-func (c *duckClient) ducks(ctx context.Context, duckGK schema.GroupKind, track bool) ([]duckv1.Duck, error) {
+func (c *duckClient) readyDuckType(ctx context.Context) (*duckv1.DuckType, error) {
 	duckType := c.duckType.DeepCopy()
 	if err := c.client.Get(ctx, client.ObjectKeyFromObject(duckType), duckType); err != nil {
 		if apierrs.IsNotFound(err) {
@@ -78,7 +144,35 @@ func (c *duckClient) ducks(ctx context.Context, duckGK schema.GroupKind, track b
 	if ready := duckType.GetConditionManager(ctx).GetCondition(duckv1.DuckTypeConditionReady); !apis.ConditionIsTrue(ready) {
 		return nil, ErrDuckTypeNotReady
 	}
+	return duckType, nil
+}
+
+func (c *duckClient) ducks(ctx context.Context, duckGK schema.GroupKind, track bool) ([]duckv1.Duck, error) {
+	_, ducks, err := c.lookup(ctx, duckGK, track)
+	return ducks, err
+}
+
+// lookup resolves the ready DuckType and the ready ducks matching the GroupKind, preferring the
+// index when set
+func (c *duckClient) lookup(ctx context.Context, duckGK schema.GroupKind, track bool) (*duckv1.DuckType, []duckv1.Duck, error) {
+	if !track {
+		if duckType, ducks, ok, err := c.index.lookup(ctx, c.duckType.Name, duckGK); ok || err != nil {
+			return duckType, ducks, err
+		}
+	}
 
+	duckType, err := c.readyDuckType(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	ducks, err := c.ducksForType(ctx, duckType, duckGK, track)
+	if err != nil {
+		return nil, nil, err
+	}
+	return duckType, ducks, nil
+}
+
+func (c *duckClient) ducksForType(ctx context.Context, duckType *duckv1.DuckType, duckGK schema.GroupKind, track bool) ([]duckv1.Duck, error) {
 	duckList := &duckv1.DuckList{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: schema.GroupVersion{Group: duckType.Spec.Group, Version: "v1"}.String(),
@@ -159,20 +253,15 @@ func (c *duckClient) List(ctx context.Context, list client.ObjectList, opts ...c
 	if err != nil {
 		return err
 	}
-	aggregateList := &unstructured.UnstructuredList{}
-	for _, duck := range ducks {
-		duckList := &unstructured.UnstructuredList{}
-		duckList.GetObjectKind().SetGroupVersionKind(schema.GroupVersionKind{
-			Group:   duck.Spec.Group,
-			Version: duck.Spec.Version,
-			Kind:    fmt.Sprintf("%sList", duck.Spec.Kind),
-		})
-		if err := c.client.List(ctx, duckList, opts...); err != nil {
-			return err
-		}
-		aggregateList.Items = append(aggregateList.Items, duckList.Items...)
+	aggregate, listErr := c.listDucks(ctx, ducks, listMode{metadataOnly: isMetadataList(list)}, opts...)
+	if aggregate == nil {
+		return listErr
 	}
-	return duck.Convert(aggregateList, list)
+	if err := duck.Convert(aggregate, list); err != nil {
+		return err
+	}
+	// a partial list error, if any
+	return listErr
 }
 
 func (c *duckClient) TrackAndList(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
@@ -180,43 +269,385 @@ func (c *duckClient) TrackAndList(ctx context.Context, list client.ObjectList, o
 	if err != nil {
 		return err
 	}
-	aggregate := &unstructured.UnstructuredList{}
-	for _, duck := range ducks {
-		duckList := &unstructured.UnstructuredList{}
-		duckList.GetObjectKind().SetGroupVersionKind(schema.GroupVersionKind{
-			Group:   duck.Spec.Group,
-			Version: duck.Spec.Version,
-			Kind:    fmt.Sprintf("%sList", duck.Spec.Kind),
+	aggregate, listErr := c.listDucks(ctx, ducks, listMode{track: true, metadataOnly: isMetadataList(list)}, opts...)
+	if aggregate == nil {
+		return listErr
+	}
+	if err := duck.Convert(aggregate, list); err != nil {
+		return err
+	}
+	// a partial list error, if any
+	return listErr
+}
+
+// AllowPartialFailure is a list option that returns the items from each duck that was listed
+// successfully, rather than failing the whole list when an individual duck fails. Failures are
+// reported by returning a *PartialListError alongside the populated list.
+type AllowPartialFailure struct{}
+
+var _ client.ListOption = AllowPartialFailure{}
+
+func (AllowPartialFailure) ApplyToList(*client.ListOptions) {}
+
+// PartialListError reports the ducks that failed to list while listing with AllowPartialFailure
+type PartialListError struct {
+	Failures []DuckListFailure
+}
+
+// DuckListFailure is the cause of a failure to list an individual duck
+type DuckListFailure struct {
+	Duck             string
+	GroupVersionKind schema.GroupVersionKind
+	Err              error
+}
+
+func (e *PartialListError) Error() string {
+	msgs := make([]string, len(e.Failures))
+	for i, failure := range e.Failures {
+		msgs[i] = fmt.Sprintf("%s: %s", failure.Duck, failure.Err)
+	}
+	return fmt.Sprintf("unable to list %d duck(s): %s", len(e.Failures), strings.Join(msgs, "; "))
+}
+
+func (e *PartialListError) Unwrap() []error {
+	errs := make([]error, len(e.Failures))
+	for i, failure := range e.Failures {
+		errs[i] = failure.Err
+	}
+	return errs
+}
+
+// listMode controls how the resources for each duck are listed
+type listMode struct {
+	// track the upstream list
+	track bool
+	// partial returns the successfully listed items when some ducks fail
+	partial bool
+	// metadataOnly lists PartialObjectMetadata rather than full objects
+	metadataOnly bool
+	// uncached lists from the API reader, when configured, rather than the client
+	uncached bool
+}
+
+func isMetadataList(list client.ObjectList) bool {
+	_, ok := list.(*metav1.PartialObjectMetadataList)
+	return ok
+}
+
+// listDucks lists the resources for each duck. When the list is paginated, ducks are listed in
+// turn until the limit is reached, otherwise ducks are listed in parallel.
+func (c *duckClient) listDucks(ctx context.Context, ducks []duckv1.Duck, mode listMode, opts ...client.ListOption) (*unstructured.UnstructuredList, error) {
+	upstreamOpts := make([]client.ListOption, 0, len(opts))
+	for _, opt := range opts {
+		if _, ok := opt.(AllowPartialFailure); ok {
+			mode.partial = true
+			continue
+		}
+		upstreamOpts = append(upstreamOpts, opt)
+	}
+
+	if listOpts := (&client.ListOptions{}).ApplyOptions(upstreamOpts); listOpts.Limit > 0 || listOpts.Continue != "" {
+		return c.listDucksPaged(ctx, ducks, mode, listOpts)
+	}
+	return c.listDucksParallel(ctx, ducks, mode, upstreamOpts...)
+}
+
+// listDucksParallel lists the resources for each duck in parallel, bounded by the client's
+// concurrency. Items are aggregated in the order of the ducks regardless of which list completes
+// first.
+func (c *duckClient) listDucksParallel(ctx context.Context, ducks []duckv1.Duck, mode listMode, opts ...client.ListOption) (*unstructured.UnstructuredList, error) {
+	duckLists := make([]*unstructured.UnstructuredList, len(ducks))
+	failures := make([]*DuckListFailure, len(ducks))
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(c.concurrency)
+	for i := range ducks {
+		duck := ducks[i]
+		g.Go(func() error {
+			duckList, err := c.listDuck(gctx, &duck, mode, opts...)
+			if err != nil {
+				if mode.partial {
+					failures[i] = &DuckListFailure{
+						Duck:             duck.Name,
+						GroupVersionKind: duckList.GroupVersionKind(),
+						Err:              err,
+					}
+					return nil
+				}
+				return err
+			}
+			duckLists[i] = duckList
+			return nil
 		})
-		if err := c.client.TrackAndList(ctx, duckList, opts...); err != nil {
-			return err
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	aggregate := &unstructured.UnstructuredList{}
+	partialErr := &PartialListError{}
+	for i, duckList := range duckLists {
+		if failures[i] != nil {
+			partialErr.Failures = append(partialErr.Failures, *failures[i])
+			continue
+		}
+		aggregate.Items = append(aggregate.Items, duckList.Items...)
+	}
+	if len(partialErr.Failures) != 0 {
+		return aggregate, partialErr
+	}
+	return aggregate, nil
+}
+
+// listDucksPaged lists a single page of resources across the ducks. Ducks are visited in name
+// order, each page resumes from the position captured in the continue token.
+//
+// Pages are listed from the API reader when configured as the controller-runtime cache does not
+// support continue tokens.
+func (c *duckClient) listDucksPaged(ctx context.Context, ducks []duckv1.Duck, mode listMode, listOpts *client.ListOptions) (*unstructured.UnstructuredList, error) {
+	mode.uncached = true
+	ducks = slices.Clone(ducks)
+	slices.SortFunc(ducks, func(a, b duckv1.Duck) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	position := duckContinue{}
+	if listOpts.Continue != "" {
+		var err error
+		if position, err = decodeDuckContinue(listOpts.Continue); err != nil {
+			return nil, err
+		}
+	}
+	start, _ := slices.BinarySearchFunc(ducks, position.Duck, func(duck duckv1.Duck, name string) int {
+		return strings.Compare(duck.Name, name)
+	})
+	if start < len(ducks) && ducks[start].Name != position.Duck {
+		// the duck is gone, resume from the next duck
+		position.Continue = ""
+	}
+
+	aggregate := &unstructured.UnstructuredList{}
+	partialErr := &PartialListError{}
+	remaining := listOpts.Limit
+	next := duckContinue{}
+	for i := start; i < len(ducks); i++ {
+		if listOpts.Limit > 0 && remaining <= 0 {
+			next = duckContinue{Duck: ducks[i].Name}
+			break
+		}
+
+		pageOpts := *listOpts
+		if pageOpts.Raw != nil {
+			pageOpts.Raw = pageOpts.Raw.DeepCopy()
+			pageOpts.Raw.Limit = 0
+			pageOpts.Raw.Continue = ""
+		}
+		if listOpts.Limit > 0 {
+			pageOpts.Limit = remaining
+		}
+		pageOpts.Continue = ""
+		if i == start {
+			pageOpts.Continue = position.Continue
+		}
+
+		duckList, err := c.listDuck(ctx, &ducks[i], mode, &pageOpts)
+		if err != nil {
+			if mode.partial {
+				partialErr.Failures = append(partialErr.Failures, DuckListFailure{
+					Duck:             ducks[i].Name,
+					GroupVersionKind: duckList.GroupVersionKind(),
+					Err:              err,
+				})
+				continue
+			}
+			return nil, err
 		}
 		aggregate.Items = append(aggregate.Items, duckList.Items...)
+		remaining -= int64(len(duckList.Items))
+		if continueToken := duckList.GetContinue(); continueToken != "" && continueToken != cacheContinueNotSupported {
+			// more items remain for the current duck
+			next = duckContinue{Duck: ducks[i].Name, Continue: continueToken}
+			break
+		}
+	}
+
+	if next.Duck != "" {
+		token, err := encodeDuckContinue(next)
+		if err != nil {
+			return nil, err
+		}
+		aggregate.SetContinue(token)
+	}
+	if len(partialErr.Failures) != 0 {
+		return aggregate, partialErr
 	}
-	return duck.Convert(aggregate, list)
+	return aggregate, nil
 }
 
-func (c *duckClient) Watch(ctx context.Context, list client.ObjectList, opts ...client.ListOption) (watch.Interface, error) {
-	panic("Watch is not implemented for duck types")
+// listDuck lists the resources for a single duck
+func (c *duckClient) listDuck(ctx context.Context, duck *duckv1.Duck, mode listMode, opts ...client.ListOption) (*unstructured.UnstructuredList, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	duckList := &unstructured.UnstructuredList{}
+	duckList.GetObjectKind().SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   duck.Spec.Group,
+		Version: duck.Spec.Version,
+		Kind:    fmt.Sprintf("%sList", duck.Spec.Kind),
+	})
+	var list client.ObjectList = duckList
+	if mode.metadataOnly {
+		list = &metav1.PartialObjectMetadataList{}
+		list.GetObjectKind().SetGroupVersionKind(duckList.GroupVersionKind())
+	}
+
+	reader := c.client
+	if mode.uncached {
+		reader = c.pager
+	}
+
+	start := time.Now()
+	var err error
+	if mode.track {
+		err = reader.TrackAndList(ctx, list, opts...)
+	} else {
+		err = reader.List(ctx, list, opts...)
+	}
+	if metadataList, ok := list.(*metav1.PartialObjectMetadataList); ok && err == nil {
+		err = metadataToUnstructured(metadataList, duckList, duck.Spec.GroupVersionKind())
+	}
+	log.V(1).Info("Listed duck", "duck", duck.Name, "items", len(duckList.Items), "duration", time.Since(start), "error", err)
+
+	return duckList, err
+}
+
+// cacheContinueNotSupported is the continue token set by the controller-runtime cache on every
+// list, it does not identify a position that can be resumed
+const cacheContinueNotSupported = "continue-not-supported"
+
+// apiReaderClient reads directly from the API server, all other operations are delegated to the
+// client
+type apiReaderClient struct {
+	client.Client
+	reader client.Reader
+}
+
+func (c *apiReaderClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	return c.reader.Get(ctx, key, obj, opts...)
+}
+
+func (c *apiReaderClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	return c.reader.List(ctx, list, opts...)
+}
+
+// metadataToUnstructured copies PartialObjectMetadata items into an unstructured list so they can
+// be aggregated with the items of other ducks
+func metadataToUnstructured(from *metav1.PartialObjectMetadataList, to *unstructured.UnstructuredList, gvk schema.GroupVersionKind) error {
+	to.SetContinue(from.GetContinue())
+	to.SetResourceVersion(from.GetResourceVersion())
+	for i := range from.Items {
+		item := from.Items[i].DeepCopy()
+		item.SetGroupVersionKind(gvk)
+		u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(item)
+		if err != nil {
+			return err
+		}
+		to.Items = append(to.Items, unstructured.Unstructured{Object: u})
+	}
+	return nil
+}
+
+// duckContinue is the position of a paginated list across ducks. The duck is the name of the next
+// duck to list, continue is the upstream continue token for that duck.
+type duckContinue struct {
+	Duck     string `json:"duck"`
+	Continue string `json:"continue,omitempty"`
+}
+
+func encodeDuckContinue(position duckContinue) (string, error) {
+	data, err := json.Marshal(position)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeDuckContinue(token string) (duckContinue, error) {
+	position := duckContinue{}
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return position, fmt.Errorf("%w: %w", ErrInvalidContinue, err)
+	}
+	if err := json.Unmarshal(data, &position); err != nil {
+		return position, fmt.Errorf("%w: %w", ErrInvalidContinue, err)
+	}
+	if position.Duck == "" {
+		return position, ErrInvalidContinue
+	}
+	return position, nil
 }
 
 func (c *duckClient) Apply(ctx context.Context, obj runtime.ApplyConfiguration, opts ...client.ApplyOption) error {
+	if err := c.setApplyConfigurationDuckVersion(ctx, obj); err != nil {
+		return err
+	}
 	return c.client.Apply(ctx, obj, opts...)
 }
 
+// setApplyConfigurationDuckVersion resolves the version for unstructured apply configurations.
+// Typed apply configurations are not mutable, they must already reference the duck's version.
+func (c *duckClient) setApplyConfigurationDuckVersion(ctx context.Context, obj runtime.ApplyConfiguration) error {
+	switch ac := obj.(type) {
+	case interface{ GetObjectKind() schema.ObjectKind }:
+		gvk := ac.GetObjectKind().GroupVersionKind()
+		duck, err := c.duck(ctx, gvk.GroupKind(), false)
+		if err != nil {
+			return err
+		}
+		gvk.Version = duck.Spec.Version
+		ac.GetObjectKind().SetGroupVersionKind(gvk)
+		return nil
+	case interface {
+		GetAPIVersion() *string
+		GetKind() *string
+	}:
+		gvk := schema.FromAPIVersionAndKind(ptr.Deref(ac.GetAPIVersion(), ""), ptr.Deref(ac.GetKind(), ""))
+		duck, err := c.duck(ctx, gvk.GroupKind(), false)
+		if err != nil {
+			return err
+		}
+		if gvk.Version != duck.Spec.Version {
+			return fmt.Errorf("apply configuration for %s must use version %q", gvk.GroupKind(), duck.Spec.Version)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unable to resolve duck for apply configuration %T", obj)
+	}
+}
+
 func (c *duckClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if err := c.setDuckVersion(ctx, obj, false); err != nil {
+		return err
+	}
 	return c.client.Create(ctx, obj, opts...)
 }
 
 func (c *duckClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	if err := c.setDuckVersion(ctx, obj, false); err != nil {
+		return err
+	}
 	return c.client.Delete(ctx, obj, opts...)
 }
 
 func (c *duckClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if err := c.setDuckVersion(ctx, obj, false); err != nil {
+		return err
+	}
 	return c.client.Update(ctx, obj, opts...)
 }
 
 func (c *duckClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if err := c.setDuckVersion(ctx, obj, false); err != nil {
+		return err
+	}
 	return c.client.Patch(ctx, obj, patch, opts...)
 }
 
@@ -240,7 +671,92 @@ func (c *duckClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ..
 }
 
 func (c *duckClient) Status() client.SubResourceWriter {
-	panic("Status sub resource client is not implemented for duck types")
+	return &duckStatusWriter{client: c}
+}
+
+// duckStatusWriter writes the status subresource of a duck typed resource. Only fields in the
+// duck's shape are sent to the server so that fields owned by the implementer are preserved.
+type duckStatusWriter struct {
+	client *duckClient
+}
+
+func (w *duckStatusWriter) Create(ctx context.Context, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+	if err := w.client.setDuckVersion(ctx, obj, false); err != nil {
+		return err
+	}
+	return w.client.client.Status().Create(ctx, obj, subResource, opts...)
+}
+
+// Update the status of the resource. The update is sent as a merge patch containing the status
+// fields defined by the duck's shape, guarded by the resource version of the object. For a typed
+// duck, the shape is the fields of the Go struct. An unstructured object is pruned to the fields
+// declared by the DuckType's schema, or sent as is if the DuckType does not define a schema.
+//
+// As with any merge patch, lists such as conditions are replaced as a whole. The object must have
+// a resource version, so the update fails rather than dropping list entries the implementer wrote
+// after the resource was read. An update without any status fields to write is also an error.
+func (w *duckStatusWriter) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	if obj.GetResourceVersion() == "" {
+		return fmt.Errorf("updating the status of %s requires a resource version, read the resource before updating it", client.ObjectKeyFromObject(obj))
+	}
+	if err := w.client.setDuckVersion(ctx, obj, false); err != nil {
+		return err
+	}
+
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return err
+	}
+	patch := map[string]interface{}{}
+	if status, ok := u["status"]; ok {
+		patch["status"] = status
+	}
+	if _, ok := obj.(*unstructured.Unstructured); ok {
+		duckType, _, err := w.client.lookup(ctx, obj.GetObjectKind().GroupVersionKind().GroupKind(), false)
+		if err != nil {
+			return err
+		}
+		if duckType.Spec.Schema != nil {
+			prune(patch, []*apiextensionsv1.JSONSchemaProps{duckType.Spec.Schema})
+		}
+	}
+	if _, ok := patch["status"]; !ok {
+		// an empty patch would report success without writing anything
+		return fmt.Errorf("no status fields to update for %s", client.ObjectKeyFromObject(obj))
+	}
+	patch["metadata"] = map[string]interface{}{
+		"resourceVersion": obj.GetResourceVersion(),
+	}
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return err
+	}
+
+	updateOpts := (&client.SubResourceUpdateOptions{}).ApplyOptions(opts)
+	patchOpts := &client.SubResourcePatchOptions{
+		PatchOptions: client.PatchOptions{
+			DryRun:          updateOpts.DryRun,
+			FieldManager:    updateOpts.FieldManager,
+			FieldValidation: updateOpts.FieldValidation,
+		},
+		SubResourceBody: updateOpts.SubResourceBody,
+	}
+
+	return w.client.client.Status().Patch(ctx, obj, client.RawPatch(types.MergePatchType, data), patchOpts)
+}
+
+func (w *duckStatusWriter) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	if err := w.client.setDuckVersion(ctx, obj, false); err != nil {
+		return err
+	}
+	return w.client.client.Status().Patch(ctx, obj, patch, opts...)
+}
+
+func (w *duckStatusWriter) Apply(ctx context.Context, obj runtime.ApplyConfiguration, opts ...client.SubResourceApplyOption) error {
+	if err := w.client.setApplyConfigurationDuckVersion(ctx, obj); err != nil {
+		return err
+	}
+	return w.client.client.Status().Apply(ctx, obj, opts...)
 }
 
 func (c *duckClient) SubResource(subResource string) client.SubResourceClient {
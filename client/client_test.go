@@ -18,7 +18,9 @@ package client_test
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -26,6 +28,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
@@ -34,6 +37,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
 	dieappsv1 "reconciler.io/dies/apis/apps/v1"
 	diebatchv1 "reconciler.io/dies/apis/batch/v1"
 	diemetav1 "reconciler.io/dies/apis/meta/v1"
@@ -194,6 +198,7 @@ func TestClient(t *testing.T) {
 	tests := map[string]struct {
 		config    *rtesting.ExpectConfig
 		duckType  string
+		opts      []duckclient.Option
 		op        func(t *testing.T, ctx context.Context, client duckclient.Client) (any, error)
 		expected  any
 		shouldErr bool
@@ -321,6 +326,50 @@ func TestClient(t *testing.T) {
 				DieDefaultTypeMetadata().
 				DieReleaseDuck(&testresources.ConditionDuck{}),
 		},
+		"typed get": {
+			config: &rtesting.ExpectConfig{
+				GivenObjects: []client.Object{
+					duckType,
+					duckDeployment,
+					duckJob,
+
+					deploymentBlue,
+					deploymentGreen,
+					jobBlue,
+					jobGreen,
+				},
+			},
+			duckType: duckType.GetName(),
+			op: func(t *testing.T, ctx context.Context, c duckclient.Client) (any, error) {
+				tc := duckclient.NewTypedForClient[*testresources.ConditionDuck, *testresources.ConditionDuckList](c)
+
+				return tc.Get(ctx, schema.GroupVersionKind{Group: "apps", Kind: "Deployment"}, types.NamespacedName{Namespace: namespace, Name: "blue"})
+			},
+			expected: deploymentBlue.
+				DieDefaultTypeMetadata().
+				DieReleaseDuck(&testresources.ConditionDuck{}),
+		},
+		"typed get unknown kind": {
+			config: &rtesting.ExpectConfig{
+				GivenObjects: []client.Object{
+					duckType,
+					duckDeployment,
+				},
+			},
+			duckType: duckType.GetName(),
+			op: func(t *testing.T, ctx context.Context, c duckclient.Client) (any, error) {
+				tc := duckclient.NewTypedForClient[*testresources.ConditionDuck, *testresources.ConditionDuckList](c)
+
+				obj, err := tc.Get(ctx, schema.GroupVersionKind{Group: "batch", Kind: "Job"}, types.NamespacedName{Namespace: namespace, Name: "blue"})
+				if !errors.Is(err, duckclient.ErrUnknownDuck) {
+					t.Errorf("expected ErrUnknownDuck, got %v", err)
+				}
+
+				return obj, err
+			},
+			expected:  (*testresources.ConditionDuck)(nil),
+			shouldErr: true,
+		},
 		"get normalized version": {
 			config: &rtesting.ExpectConfig{
 				GivenObjects: []client.Object{
@@ -427,6 +476,30 @@ func TestClient(t *testing.T) {
 				},
 			},
 		},
+		"typed list all": {
+			config: &rtesting.ExpectConfig{
+				GivenObjects: []client.Object{
+					duckType,
+					duckDeployment,
+					duckJob,
+
+					deploymentBlue,
+					deploymentGreen,
+					jobBlue,
+					jobGreen,
+				},
+			},
+			duckType: duckType.GetName(),
+			op: func(t *testing.T, ctx context.Context, c duckclient.Client) (any, error) {
+				tc := duckclient.NewTypedForClient[*testresources.ConditionDuck, *testresources.ConditionDuckList](c)
+
+				return tc.ListAll(ctx, client.MatchingLabels{"color": "blue"})
+			},
+			expected: []*testresources.ConditionDuck{
+				testresources.ConditionDuckBlank.DieFeedDuck(deploymentBlue.DieDefaultTypeMetadata()).DieReleasePtr(),
+				testresources.ConditionDuckBlank.DieFeedDuck(jobBlue.DieDefaultTypeMetadata()).DieReleasePtr(),
+			},
+		},
 		"list normalized version": {
 			config: &rtesting.ExpectConfig{
 				GivenObjects: []client.Object{
@@ -460,6 +533,150 @@ func TestClient(t *testing.T) {
 				},
 			},
 		},
+		"list unbounded concurrency": {
+			config: &rtesting.ExpectConfig{
+				GivenObjects: []client.Object{
+					duckType,
+					duckDeployment,
+					duckJob,
+
+					deploymentBlue,
+					deploymentGreen,
+					jobBlue,
+					jobGreen,
+				},
+			},
+			duckType: duckType.GetName(),
+			opts:     []duckclient.Option{duckclient.WithListConcurrency(0)},
+			op: func(t *testing.T, ctx context.Context, c duckclient.Client) (any, error) {
+				list := &testresources.ConditionDuckList{}
+
+				err := c.List(ctx, list)
+
+				return list, err
+			},
+			expected: &testresources.ConditionDuckList{
+				Items: []testresources.ConditionDuck{
+					testresources.ConditionDuckBlank.DieFeedDuck(deploymentBlue.DieDefaultTypeMetadata()).DieRelease(),
+					testresources.ConditionDuckBlank.DieFeedDuck(deploymentGreen.DieDefaultTypeMetadata()).DieRelease(),
+					testresources.ConditionDuckBlank.DieFeedDuck(jobBlue.DieDefaultTypeMetadata()).DieRelease(),
+					testresources.ConditionDuckBlank.DieFeedDuck(jobGreen.DieDefaultTypeMetadata()).DieRelease(),
+				},
+			},
+		},
+		"list with limit": {
+			config: &rtesting.ExpectConfig{
+				GivenObjects: []client.Object{
+					duckType,
+					duckDeployment,
+					duckJob,
+				},
+				APIGivenObjects: []client.Object{
+					deploymentBlue,
+					deploymentGreen,
+					jobBlue,
+					jobGreen,
+				},
+			},
+			duckType: duckType.GetName(),
+			op: func(t *testing.T, ctx context.Context, c duckclient.Client) (any, error) {
+				list := &testresources.ConditionDuckList{}
+
+				err := c.List(ctx, list, client.Limit(2))
+
+				return list, err
+			},
+			expected: &testresources.ConditionDuckList{
+				ListMeta: metav1.ListMeta{
+					Continue: base64.RawURLEncoding.EncodeToString([]byte(`{"duck":"jobs.batch"}`)),
+				},
+				Items: []testresources.ConditionDuck{
+					testresources.ConditionDuckBlank.DieFeedDuck(deploymentBlue.DieDefaultTypeMetadata()).DieRelease(),
+					testresources.ConditionDuckBlank.DieFeedDuck(deploymentGreen.DieDefaultTypeMetadata()).DieRelease(),
+				},
+			},
+		},
+		"list with continue": {
+			config: &rtesting.ExpectConfig{
+				GivenObjects: []client.Object{
+					duckType,
+					duckDeployment,
+					duckJob,
+				},
+				APIGivenObjects: []client.Object{
+					deploymentBlue,
+					deploymentGreen,
+					jobBlue,
+					jobGreen,
+				},
+			},
+			duckType: duckType.GetName(),
+			op: func(t *testing.T, ctx context.Context, c duckclient.Client) (any, error) {
+				list := &testresources.ConditionDuckList{}
+
+				err := c.List(ctx, list, client.Limit(2), client.Continue(base64.RawURLEncoding.EncodeToString([]byte(`{"duck":"jobs.batch"}`))))
+
+				return list, err
+			},
+			expected: &testresources.ConditionDuckList{
+				Items: []testresources.ConditionDuck{
+					testresources.ConditionDuckBlank.DieFeedDuck(jobBlue.DieDefaultTypeMetadata()).DieRelease(),
+					testresources.ConditionDuckBlank.DieFeedDuck(jobGreen.DieDefaultTypeMetadata()).DieRelease(),
+				},
+			},
+		},
+		"list with invalid continue": {
+			config: &rtesting.ExpectConfig{
+				GivenObjects: []client.Object{
+					duckType,
+					duckDeployment,
+					duckJob,
+				},
+			},
+			duckType: duckType.GetName(),
+			op: func(t *testing.T, ctx context.Context, c duckclient.Client) (any, error) {
+				list := &testresources.ConditionDuckList{}
+
+				err := c.List(ctx, list, client.Continue("not a token"))
+				if !errors.Is(err, duckclient.ErrInvalidContinue) {
+					t.Errorf("expected ErrInvalidContinue, got %v", err)
+				}
+
+				return list, err
+			},
+			expected:  &testresources.ConditionDuckList{},
+			shouldErr: true,
+		},
+		"list metadata": {
+			config: &rtesting.ExpectConfig{
+				GivenObjects: []client.Object{
+					duckType,
+					duckDeployment,
+					duckJob,
+
+					deploymentBlue,
+					deploymentGreen,
+					jobBlue,
+					jobGreen,
+				},
+			},
+			duckType: duckType.GetName(),
+			op: func(t *testing.T, ctx context.Context, c duckclient.Client) (any, error) {
+				list := &metav1.PartialObjectMetadataList{}
+
+				err := c.List(ctx, list, client.MatchingLabels{"color": "blue"})
+
+				items := []string{}
+				for _, item := range list.Items {
+					items = append(items, fmt.Sprintf("%s %s", item.GroupVersionKind(), client.ObjectKeyFromObject(&item)))
+				}
+				return items, err
+			},
+			expected: []string{
+				"apps/v1, Kind=Deployment test-namespace/blue",
+				"batch/v1, Kind=Job test-namespace/blue",
+			},
+		},
 		"list and track": {
 			config: &rtesting.ExpectConfig{
 				GivenObjects: []client.Object{
@@ -479,6 +696,8 @@ func TestClient(t *testing.T) {
 				},
 			},
 			duckType: duckType.GetName(),
+			// tracks are asserted in order
+			opts: []duckclient.Option{duckclient.WithListConcurrency(1)},
 			op: func(t *testing.T, ctx context.Context, c duckclient.Client) (any, error) {
 				list := &testresources.ConditionDuckList{}
 
@@ -556,6 +775,72 @@ func TestClient(t *testing.T) {
 				},
 			},
 		},
+		"list failure": {
+			config: &rtesting.ExpectConfig{
+				GivenObjects: []client.Object{
+					duckType,
+					duckDeployment,
+					duckJob,
+
+					deploymentBlue,
+					deploymentGreen,
+					jobBlue,
+					jobGreen,
+				},
+				WithReactors: []rtesting.ReactionFunc{
+					rtesting.InduceFailure("list", "JobList"),
+				},
+			},
+			duckType: duckType.GetName(),
+			op: func(t *testing.T, ctx context.Context, c duckclient.Client) (any, error) {
+				list := &testresources.ConditionDuckList{}
+
+				err := c.List(ctx, list)
+
+				return list, err
+			},
+			expected:  &testresources.ConditionDuckList{},
+			shouldErr: true,
+		},
+		"list partial failure": {
+			config: &rtesting.ExpectConfig{
+				GivenObjects: []client.Object{
+					duckType,
+					duckDeployment,
+					duckJob,
+
+					deploymentBlue,
+					deploymentGreen,
+					jobBlue,
+					jobGreen,
+				},
+				WithReactors: []rtesting.ReactionFunc{
+					rtesting.InduceFailure("list", "JobList"),
+				},
+			},
+			duckType: duckType.GetName(),
+			op: func(t *testing.T, ctx context.Context, c duckclient.Client) (any, error) {
+				list := &testresources.ConditionDuckList{}
+
+				err := c.List(ctx, list, duckclient.AllowPartialFailure{})
+
+				var partialErr *duckclient.PartialListError
+				if !errors.As(err, &partialErr) {
+					t.Errorf("expected PartialListError, got %v", err)
+				} else if len(partialErr.Failures) != 1 || partialErr.Failures[0].Duck != duckJob.GetName() {
+					t.Errorf("unexpected failures: %v", partialErr.Failures)
+				}
+
+				return list, err
+			},
+			expected: &testresources.ConditionDuckList{
+				Items: []testresources.ConditionDuck{
+					testresources.ConditionDuckBlank.DieFeedDuck(deploymentBlue.DieDefaultTypeMetadata()).DieRelease(),
+					testresources.ConditionDuckBlank.DieFeedDuck(deploymentGreen.DieDefaultTypeMetadata()).DieRelease(),
+				},
+			},
+			shouldErr: true,
+		},
 		"list api not marked as a duck": {
 			config: &rtesting.ExpectConfig{
 				GivenObjects: []client.Object{
@@ -588,6 +873,427 @@ func TestClient(t *testing.T) {
 			},
 			shouldErr: true,
 		},
+		"update status": {
+			config: &rtesting.ExpectConfig{
+				GivenObjects: []client.Object{
+					duckType,
+					duckDeployment,
+					duckJob,
+
+					deploymentBlue,
+					deploymentGreen,
+					jobBlue,
+					jobGreen,
+				},
+				StatusSubResourceTypes: []client.Object{
+					&appsv1.Deployment{},
+				},
+				ExpectStatusPatches: []rtesting.PatchRef{
+					{
+						Group:       "apps",
+						Kind:        "Deployment",
+						Namespace:   namespace,
+						Name:        "blue",
+						SubResource: "status",
+						PatchType:   types.MergePatchType,
+						Patch:       []byte(`{"metadata":{"resourceVersion":"999"},"status":{"conditions":[{"lastTransitionTime":null,"message":"","reason":"Bound","status":"True","type":"Consumed"}]}}`),
+					},
+				},
+			},
+			duckType: duckType.GetName(),
+			op: func(t *testing.T, ctx context.Context, c duckclient.Client) (any, error) {
+				obj := &testresources.ConditionDuck{
+					TypeMeta: metav1.TypeMeta{
+						APIVersion: "apps/v1beta1",
+						Kind:       "Deployment",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:       namespace,
+						Name:            "blue",
+						ResourceVersion: "999",
+					},
+					Status: testresources.ConditionDuckStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:   "Consumed",
+								Status: metav1.ConditionTrue,
+								Reason: "Bound",
+							},
+						},
+					},
+				}
+
+				err := c.Status().Update(ctx, obj)
+
+				return nil, err
+			},
+		},
+		"update status unstructured pruned to schema": {
+			config: &rtesting.ExpectConfig{
+				GivenObjects: []client.Object{
+					duckType.
+						SpecDie(func(d *duckv1.DuckTypeSpecDie) {
+							d.Schema(&apiextensionsv1.JSONSchemaProps{
+								Type: "object",
+								Properties: map[string]apiextensionsv1.JSONSchemaProps{
+									"status": {
+										Type: "object",
+										Properties: map[string]apiextensionsv1.JSONSchemaProps{
+											"conditions": {
+												Type: "array",
+												Items: &apiextensionsv1.JSONSchemaPropsOrArray{
+													Schema: &apiextensionsv1.JSONSchemaProps{
+														Type:                   "object",
+														XPreserveUnknownFields: ptr.To(true),
+													},
+												},
+											},
+										},
+									},
+								},
+							})
+						}),
+					duckDeployment,
+
+					deploymentBlue,
+				},
+				StatusSubResourceTypes: []client.Object{
+					&appsv1.Deployment{},
+				},
+				ExpectStatusPatches: []rtesting.PatchRef{
+					{
+						Group:       "apps",
+						Kind:        "Deployment",
+						Namespace:   namespace,
+						Name:        "blue",
+						SubResource: "status",
+						PatchType:   types.MergePatchType,
+						Patch:       []byte(`{"metadata":{"resourceVersion":"999"},"status":{"conditions":[{"reason":"Bound","status":"True","type":"Consumed"}]}}`),
+					},
+				},
+			},
+			duckType: duckType.GetName(),
+			op: func(t *testing.T, ctx context.Context, c duckclient.Client) (any, error) {
+				obj := &unstructured.Unstructured{
+					Object: map[string]interface{}{
+						"apiVersion": "apps/v1",
+						"kind":       "Deployment",
+						"metadata": map[string]interface{}{
+							"namespace":       namespace,
+							"name":            "blue",
+							"resourceVersion": "999",
+						},
+						"status": map[string]interface{}{
+							"conditions": []interface{}{
+								map[string]interface{}{
+									"type":   "Consumed",
+									"status": "True",
+									"reason": "Bound",
+								},
+							},
+							// owned by the implementer
+							"replicas": int64(2),
+						},
+					},
+				}
+
+				err := c.Status().Update(ctx, obj)
+
+				return nil, err
+			},
+		},
+		"update status without a resource version": {
+			config: &rtesting.ExpectConfig{
+				GivenObjects: []client.Object{
+					duckType,
+					duckDeployment,
+
+					deploymentBlue,
+				},
+				StatusSubResourceTypes: []client.Object{
+					&appsv1.Deployment{},
+				},
+			},
+			duckType: duckType.GetName(),
+			op: func(t *testing.T, ctx context.Context, c duckclient.Client) (any, error) {
+				obj := &testresources.ConditionDuck{
+					TypeMeta: metav1.TypeMeta{
+						APIVersion: "apps/v1",
+						Kind:       "Deployment",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: namespace,
+						Name:      "blue",
+					},
+					Status: testresources.ConditionDuckStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:   "Consumed",
+								Status: metav1.ConditionTrue,
+								Reason: "Bound",
+							},
+						},
+					},
+				}
+
+				// the implementer's conditions would be replaced
+				err := c.Status().Update(ctx, obj)
+
+				return nil, err
+			},
+			shouldErr: true,
+		},
+		"update status unstructured schema without status": {
+			config: &rtesting.ExpectConfig{
+				GivenObjects: []client.Object{
+					duckType.
+						SpecDie(func(d *duckv1.DuckTypeSpecDie) {
+							d.Schema(&apiextensionsv1.JSONSchemaProps{
+								Type: "object",
+								Properties: map[string]apiextensionsv1.JSONSchemaProps{
+									"spec": {
+										Type:                   "object",
+										XPreserveUnknownFields: ptr.To(true),
+									},
+								},
+							})
+						}),
+					duckDeployment,
+
+					deploymentBlue,
+				},
+				StatusSubResourceTypes: []client.Object{
+					&appsv1.Deployment{},
+				},
+			},
+			duckType: duckType.GetName(),
+			op: func(t *testing.T, ctx context.Context, c duckclient.Client) (any, error) {
+				obj := &unstructured.Unstructured{
+					Object: map[string]interface{}{
+						"apiVersion": "apps/v1",
+						"kind":       "Deployment",
+						"metadata": map[string]interface{}{
+							"namespace":       namespace,
+							"name":            "blue",
+							"resourceVersion": "999",
+						},
+						"status": map[string]interface{}{
+							"replicas": int64(2),
+						},
+					},
+				}
+
+				err := c.Status().Update(ctx, obj)
+
+				return nil, err
+			},
+			shouldErr: true,
+		},
+		"update status unknown kind": {
+			config: &rtesting.ExpectConfig{
+				GivenObjects: []client.Object{
+					duckType,
+					duckDeployment,
+
+					deploymentBlue,
+				},
+			},
+			duckType: duckType.GetName(),
+			op: func(t *testing.T, ctx context.Context, c duckclient.Client) (any, error) {
+				obj := &testresources.ConditionDuck{
+					TypeMeta: metav1.TypeMeta{
+						APIVersion: "batch/v1",
+						Kind:       "Job",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:       namespace,
+						Name:            "blue",
+						ResourceVersion: "999",
+					},
+				}
+
+				err := c.Status().Update(ctx, obj)
+
+				if !errors.Is(err, duckclient.ErrUnknownDuck) {
+					t.Errorf("expected err to be ErrUnknownDuck, got: %s", err)
+				}
+
+				return nil, err
+			},
+			shouldErr: true,
+		},
+		"delete normalized version": {
+			config: &rtesting.ExpectConfig{
+				GivenObjects: []client.Object{
+					duckType,
+					duckDeployment,
+					duckJob,
+
+					deploymentBlue,
+					deploymentGreen,
+					jobBlue,
+					jobGreen,
+				},
+				ExpectDeletes: []rtesting.DeleteRef{
+					{Group: "apps", Kind: "Deployment", Namespace: namespace, Name: "blue"},
+				},
+			},
+			duckType: duckType.GetName(),
+			op: func(t *testing.T, ctx context.Context, c duckclient.Client) (any, error) {
+				obj := &testresources.ConditionDuck{
+					TypeMeta: metav1.TypeMeta{
+						APIVersion: "apps/v1beta1",
+						Kind:       "Deployment",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: namespace,
+						Name:      "blue",
+					},
+				}
+
+				err := c.Delete(ctx, obj)
+
+				return nil, err
+			},
+		},
+		"patch normalized version": {
+			config: &rtesting.ExpectConfig{
+				GivenObjects: []client.Object{
+					duckType,
+					duckDeployment,
+					duckJob,
+
+					deploymentBlue,
+					deploymentGreen,
+					jobBlue,
+					jobGreen,
+				},
+				ExpectPatches: []rtesting.PatchRef{
+					{
+						Group:     "apps",
+						Kind:      "Deployment",
+						Namespace: namespace,
+						Name:      "blue",
+						PatchType: types.MergePatchType,
+						Patch:     []byte(`{"metadata":{"labels":{"color":"red"}}}`),
+					},
+				},
+			},
+			duckType: duckType.GetName(),
+			op: func(t *testing.T, ctx context.Context, c duckclient.Client) (any, error) {
+				obj := &testresources.ConditionDuck{
+					TypeMeta: metav1.TypeMeta{
+						APIVersion: "apps/v1beta1",
+						Kind:       "Deployment",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: namespace,
+						Name:      "blue",
+					},
+				}
+
+				err := c.Patch(ctx, obj, client.RawPatch(types.MergePatchType, []byte(`{"metadata":{"labels":{"color":"red"}}}`)))
+
+				return nil, err
+			},
+		},
+		"patch unknown kind": {
+			config: &rtesting.ExpectConfig{
+				GivenObjects: []client.Object{
+					duckType,
+					duckDeployment,
+				},
+			},
+			duckType: duckType.GetName(),
+			op: func(t *testing.T, ctx context.Context, c duckclient.Client) (any, error) {
+				obj := &testresources.ConditionDuck{
+					TypeMeta: metav1.TypeMeta{
+						APIVersion: "batch/v1",
+						Kind:       "Job",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: namespace,
+						Name:      "blue",
+					},
+				}
+
+				err := c.Patch(ctx, obj, client.RawPatch(types.MergePatchType, []byte(`{"metadata":{"labels":{"color":"red"}}}`)))
+
+				if !errors.Is(err, duckclient.ErrUnknownDuck) {
+					t.Errorf("expected err to be ErrUnknownDuck, got: %s", err)
+				}
+
+				return nil, err
+			},
+			shouldErr: true,
+		},
+		"create unknown kind": {
+			config: &rtesting.ExpectConfig{
+				GivenObjects: []client.Object{
+					duckType,
+					duckDeployment,
+				},
+			},
+			duckType: duckType.GetName(),
+			op: func(t *testing.T, ctx context.Context, c duckclient.Client) (any, error) {
+				obj := &testresources.ConditionDuck{
+					TypeMeta: metav1.TypeMeta{
+						APIVersion: "batch/v1",
+						Kind:       "Job",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: namespace,
+						Name:      "blue",
+					},
+				}
+
+				err := c.Create(ctx, obj)
+
+				if !errors.Is(err, duckclient.ErrUnknownDuck) {
+					t.Errorf("expected err to be ErrUnknownDuck, got: %s", err)
+				}
+
+				return nil, err
+			},
+			shouldErr: true,
+		},
+		"update duck type not ready": {
+			config: &rtesting.ExpectConfig{
+				GivenObjects: []client.Object{
+					duckType.
+						StatusDie(func(d *duckv1.DuckTypeStatusDie) {
+							d.ConditionDie(duckv1.DuckTypeConditionReady, func(d *diemetav1.ConditionDie) {
+								d.Status(metav1.ConditionFalse)
+							})
+						}),
+					duckDeployment,
+
+					deploymentBlue,
+				},
+			},
+			duckType: duckType.GetName(),
+			op: func(t *testing.T, ctx context.Context, c duckclient.Client) (any, error) {
+				obj := &testresources.ConditionDuck{
+					TypeMeta: metav1.TypeMeta{
+						APIVersion: "apps/v1",
+						Kind:       "Deployment",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: namespace,
+						Name:      "blue",
+					},
+				}
+
+				err := c.Update(ctx, obj)
+
+				if !errors.Is(err, duckclient.ErrDuckTypeNotReady) {
+					t.Errorf("expected err to be ErrDuckTypeNotReady, got: %s", err)
+				}
+
+				return nil, err
+			},
+			shouldErr: true,
+		},
 		"delete all": {
 			config: &rtesting.ExpectConfig{
 				GivenObjects: []client.Object{
@@ -772,7 +1478,7 @@ func TestClient(t *testing.T) {
 			ctx = reconcilers.StashResourceType(ctx, &corev1.Pod{})
 
 			config := test.config.Config()
-			client := duckclient.New(test.duckType, config)
+			client := duckclient.New(test.duckType, config, test.opts...)
 
 			actual, err := test.op(t, ctx, client)
 			if err != nil && !test.shouldErr {
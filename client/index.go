@@ -0,0 +1,318 @@
+/*
+Copyright 2025 the original author or authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/go-logr/logr"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	toolscache "k8s.io/client-go/tools/cache"
+	"reconciler.io/runtime/apis"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	duckv1 "reconciler.io/ducks/api/v1"
+)
+
+// DuckIndex holds a DuckType and its ready Ducks in memory, keyed by the GroupKind of each
+// implementer. The index is backed by the manager's informers and is invalidated by DuckType and
+// Duck events, so resolving a duck does not require API calls.
+type DuckIndex struct {
+	name  string
+	cache cache.Cache
+
+	m                sync.Mutex
+	dirty            bool
+	duckTypeInformer cache.Informer
+	duckInformer     cache.Informer
+	duckRegistration toolscache.ResourceEventHandlerRegistration
+	duckInformerGVK  schema.GroupVersionKind
+	duckType         *duckv1.DuckType
+	ducks            []duckv1.Duck
+	ducksByGroupKind map[schema.GroupKind][]duckv1.Duck
+}
+
+// NewDuckIndex creates an index for the named DuckType. The index is populated once the manager
+// is started.
+func NewDuckIndex(mgr manager.Manager, duckType string) (*DuckIndex, error) {
+	index := &DuckIndex{
+		name:  duckType,
+		cache: mgr.GetCache(),
+		dirty: true,
+	}
+
+	if err := mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+		log := ctrl.Log.WithName("DuckIndex").WithValues("duckType", duckType)
+		ctx = logr.NewContext(ctx, log)
+
+		duckTypeInformer, err := index.cache.GetInformer(ctx, &duckv1.DuckType{}, cache.BlockUntilSynced(false))
+		if err != nil {
+			return err
+		}
+		onDuckType := func(obj interface{}) {
+			if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if r, ok := obj.(*duckv1.DuckType); !ok || r.Name != duckType {
+				return
+			}
+			index.invalidate()
+			index.informOnDucks(ctx)
+		}
+		duckTypeRegistration, err := duckTypeInformer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+			AddFunc: onDuckType,
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				onDuckType(newObj)
+			},
+			DeleteFunc: onDuckType,
+		})
+		if err != nil {
+			return err
+		}
+		index.m.Lock()
+		index.duckTypeInformer = duckTypeInformer
+		index.m.Unlock()
+
+		<-ctx.Done()
+
+		index.m.Lock()
+		defer index.m.Unlock()
+
+		if err := duckTypeInformer.RemoveEventHandler(duckTypeRegistration); err != nil {
+			return err
+		}
+		if index.duckInformer != nil {
+			if err := index.duckInformer.RemoveEventHandler(index.duckRegistration); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})); err != nil {
+		return nil, err
+	}
+
+	return index, nil
+}
+
+func (i *DuckIndex) invalidate() {
+	i.m.Lock()
+	defer i.m.Unlock()
+
+	i.dirty = true
+}
+
+// informOnDucks starts an informer for the ducks of the DuckType, replacing an existing informer
+// if the DuckType's group or kind changed
+func (i *DuckIndex) informOnDucks(ctx context.Context) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	duckType := &duckv1.DuckType{}
+	if err := i.cache.Get(ctx, client.ObjectKey{Name: i.name}, duckType); err != nil {
+		if !apierrs.IsNotFound(err) {
+			log.Error(err, "Unable to get duck type")
+		}
+		return
+	}
+	if err := duckType.Default(ctx, duckType); err != nil {
+		log.Error(err, "Unable to default duck type")
+		return
+	}
+	gvk := schema.GroupVersionKind{
+		Group:   duckType.Spec.Group,
+		Version: "v1",
+		Kind:    duckType.Spec.Kind,
+	}
+
+	i.m.Lock()
+	defer i.m.Unlock()
+
+	if i.duckInformer != nil {
+		if i.duckInformerGVK == gvk {
+			// already informing
+			return
+		}
+		if err := i.duckInformer.RemoveEventHandler(i.duckRegistration); err != nil {
+			log.Error(err, "Unable to stop duck informer")
+			return
+		}
+		i.duckInformer = nil
+	}
+
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(gvk)
+	duckInformer, err := i.cache.GetInformer(ctx, u, cache.BlockUntilSynced(false))
+	if err != nil {
+		log.Error(err, "Unable to start duck informer")
+		return
+	}
+	duckRegistration, err := duckInformer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			i.invalidate()
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			i.invalidate()
+		},
+		DeleteFunc: func(obj interface{}) {
+			i.invalidate()
+		},
+	})
+	if err != nil {
+		log.Error(err, "Unable to handle duck events")
+		return
+	}
+	i.duckInformer = duckInformer
+	i.duckRegistration = duckRegistration
+	i.duckInformerGVK = gvk
+	i.dirty = true
+}
+
+// synced is true when the informers backing the index have synced. Must be called while holding
+// the lock.
+func (i *DuckIndex) synced() bool {
+	if i.duckTypeInformer == nil || !i.duckTypeInformer.HasSynced() {
+		return false
+	}
+	if i.duckInformer != nil && !i.duckInformer.HasSynced() {
+		return false
+	}
+	return true
+}
+
+// refresh rebuilds the index from the informer caches if it was invalidated. Must be called while
+// holding the lock.
+func (i *DuckIndex) refresh(ctx context.Context) error {
+	if !i.dirty {
+		return nil
+	}
+
+	duckType := &duckv1.DuckType{}
+	if err := i.cache.Get(ctx, client.ObjectKey{Name: i.name}, duckType); err != nil {
+		if !apierrs.IsNotFound(err) {
+			return err
+		}
+		duckType = nil
+	}
+	ducks := []duckv1.Duck{}
+	ducksByGroupKind := map[schema.GroupKind][]duckv1.Duck{}
+	if duckType != nil {
+		if err := duckType.Default(ctx, duckType); err != nil {
+			return err
+		}
+		if i.duckInformer != nil {
+			duckList := &unstructured.UnstructuredList{}
+			duckList.SetGroupVersionKind(schema.GroupVersionKind{
+				Group:   duckType.Spec.Group,
+				Version: "v1",
+				Kind:    duckType.Spec.ListKind,
+			})
+			if err := i.cache.List(ctx, duckList); err != nil {
+				return err
+			}
+			for _, u := range duckList.Items {
+				duck := duckv1.Duck{}
+				if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, &duck); err != nil {
+					return err
+				}
+				if ready := duck.GetConditionManager(ctx).GetCondition(duckv1.DuckConditionReady); !apis.ConditionIsTrue(ready) {
+					// ignore ducks that are not ready, most likely the API does not exist
+					continue
+				}
+				ducks = append(ducks, duck)
+			}
+			slices.SortFunc(ducks, func(a, b duckv1.Duck) int {
+				return strings.Compare(a.Name, b.Name)
+			})
+			for _, duck := range ducks {
+				// more than one duck may claim a GroupKind, callers treat that as ambiguous
+				gk := schema.GroupKind{Group: duck.Spec.Group, Kind: duck.Spec.Kind}
+				ducksByGroupKind[gk] = append(ducksByGroupKind[gk], duck)
+			}
+		}
+	}
+
+	i.duckType = duckType
+	i.ducks = ducks
+	i.ducksByGroupKind = ducksByGroupKind
+	i.dirty = false
+
+	return nil
+}
+
+// lookup resolves the ready DuckType and the ready ducks matching the GroupKind, with the same
+// semantics as the duck client. The result is not ok when the index is unable to answer, the
+// caller should fall back to reading from the API.
+func (i *DuckIndex) lookup(ctx context.Context, name string, duckGK schema.GroupKind) (*duckv1.DuckType, []duckv1.Duck, bool, error) {
+	if i == nil || i.name != name {
+		return nil, nil, false, nil
+	}
+
+	i.m.Lock()
+	defer i.m.Unlock()
+
+	if !i.synced() {
+		return nil, nil, false, nil
+	}
+	if err := i.refresh(ctx); err != nil {
+		return nil, nil, false, err
+	}
+
+	if i.duckType == nil {
+		return nil, nil, true, ErrUnknownDuckType
+	}
+	if ready := i.duckType.GetConditionManager(ctx).GetCondition(duckv1.DuckTypeConditionReady); !apis.ConditionIsTrue(ready) {
+		return nil, nil, true, ErrDuckTypeNotReady
+	}
+	if i.duckInformer == nil {
+		// the duck informer has not started yet
+		return nil, nil, false, nil
+	}
+
+	duckType := i.duckType.DeepCopy()
+	if duckGK.Empty() {
+		ducks := make([]duckv1.Duck, len(i.ducks))
+		for j := range i.ducks {
+			i.ducks[j].DeepCopyInto(&ducks[j])
+		}
+		return duckType, ducks, true, nil
+	}
+	// match the duck's kind or list kind, as the duck client does
+	matches := slices.Clone(i.ducksByGroupKind[duckGK])
+	if strings.HasSuffix(duckGK.Kind, "List") {
+		matches = append(matches, i.ducksByGroupKind[schema.GroupKind{Group: duckGK.Group, Kind: strings.TrimSuffix(duckGK.Kind, "List")}]...)
+		slices.SortFunc(matches, func(a, b duckv1.Duck) int {
+			return strings.Compare(a.Name, b.Name)
+		})
+	}
+	if len(matches) == 0 {
+		return nil, nil, true, ErrUnknownDuck
+	}
+	ducks := make([]duckv1.Duck, len(matches))
+	for j := range matches {
+		matches[j].DeepCopyInto(&ducks[j])
+	}
+	return duckType, ducks, true, nil
+}
@@ -0,0 +1,273 @@
+/*
+Copyright 2025 the original author or authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	toolscache "k8s.io/client-go/tools/cache"
+	"reconciler.io/runtime/apis"
+	"reconciler.io/runtime/reconcilers"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllertest"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	duckv1 "reconciler.io/ducks/api/v1"
+	duckclient "reconciler.io/ducks/client"
+	"reconciler.io/ducks/internal/testresources"
+)
+
+func TestDuckIndex(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(duckv1.AddToScheme(scheme))
+	duckGVK := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "ConditionDuck"}
+	scheme.AddKnownTypeWithName(duckGVK, &duckv1.Duck{})
+	scheme.AddKnownTypeWithName(duckGVK.GroupVersion().WithKind("ConditionDuckList"), &duckv1.DuckList{})
+
+	duckType := &duckv1.DuckType{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "conditionducks.example.com",
+		},
+		Spec: duckv1.DuckTypeSpec{
+			Group:  "example.com",
+			Plural: "conditionducks",
+			Kind:   "ConditionDuck",
+		},
+		Status: duckv1.DuckTypeStatus{
+			Status: apis.Status{
+				Conditions: []metav1.Condition{
+					{Type: duckv1.DuckTypeConditionReady, Status: metav1.ConditionTrue},
+				},
+			},
+		},
+	}
+	duckDeployment := readyDuck("deployments.apps", "apps", "v1", "Deployment")
+
+	deployment := &testresources.ConditionDuck{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "apps/v1beta1",
+			Kind:       "Deployment",
+		},
+	}
+
+	type fixture struct {
+		ctx              context.Context
+		backing          client.Client
+		duckTypeInformer *registeringInformer
+		duckInformer     *registeringInformer
+		client           duckclient.Client
+	}
+	setup := func(t *testing.T, synced bool, objs ...client.Object) fixture {
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+
+		given := make([]client.Object, len(objs))
+		for i := range objs {
+			given[i] = objs[i].DeepCopyObject().(client.Object)
+		}
+		backing := fake.NewClientBuilder().WithScheme(scheme).WithObjects(given...).Build()
+
+		duckTypeInformer := newRegisteringInformer(synced)
+		duckInformer := newRegisteringInformer(true)
+		mgr := &indexManager{
+			cache: &indexCache{
+				FakeInformers: &informertest.FakeInformers{
+					Scheme: scheme,
+					InformersByGVK: map[schema.GroupVersionKind]toolscache.SharedIndexInformer{
+						duckv1.GroupVersion.WithKind("DuckType"): duckTypeInformer,
+						duckGVK:                                  duckInformer,
+					},
+				},
+				Reader: backing,
+			},
+		}
+		index, err := duckclient.NewDuckIndex(mgr, duckType.Name)
+		if err != nil {
+			t.Fatalf("unexpected err: %s", err)
+		}
+		for _, r := range mgr.runnables {
+			go func() {
+				_ = r.Start(ctx)
+			}()
+		}
+		duckTypeInformer.waitForHandler(t)
+
+		// the api has no duck types, only the index is able to resolve ducks
+		api := fake.NewClientBuilder().WithScheme(scheme).Build()
+		c := duckclient.New(duckType.Name, reconcilers.Config{Client: api, APIReader: api}, duckclient.WithIndex(index))
+
+		return fixture{
+			ctx:              ctx,
+			backing:          backing,
+			duckTypeInformer: duckTypeInformer,
+			duckInformer:     duckInformer,
+			client:           c,
+		}
+	}
+	expectVersion := func(t *testing.T, c duckclient.Client, version string) {
+		t.Helper()
+
+		gvk, err := c.GroupVersionKindFor(deployment)
+		if err != nil {
+			t.Fatalf("unexpected err: %s", err)
+		}
+		if gvk.Version != version {
+			t.Errorf("expected version %q, got %q", version, gvk.Version)
+		}
+	}
+	expectErr := func(t *testing.T, c duckclient.Client, expected error) {
+		t.Helper()
+
+		if _, err := c.GroupVersionKindFor(deployment); !errors.Is(err, expected) {
+			t.Errorf("expected err to be %q, got: %v", expected, err)
+		}
+	}
+
+	t.Run("resolves ducks from the index", func(t *testing.T) {
+		f := setup(t, true, duckType, duckDeployment)
+		f.duckTypeInformer.Add(duckType)
+
+		expectVersion(t, f.client, "v1")
+	})
+
+	t.Run("falls back to the api until synced", func(t *testing.T) {
+		f := setup(t, false, duckType, duckDeployment)
+		f.duckTypeInformer.Add(duckType)
+
+		expectErr(t, f.client, duckclient.ErrUnknownDuckType)
+
+		f.duckTypeInformer.Synced()
+		expectVersion(t, f.client, "v1")
+	})
+
+	t.Run("invalidated by duck events", func(t *testing.T) {
+		f := setup(t, true, duckType, duckDeployment)
+		f.duckTypeInformer.Add(duckType)
+		expectVersion(t, f.client, "v1")
+
+		duck := &duckv1.Duck{TypeMeta: duckDeployment.TypeMeta}
+		if err := f.backing.Get(f.ctx, client.ObjectKeyFromObject(duckDeployment), duck); err != nil {
+			t.Fatalf("unexpected err: %s", err)
+		}
+		old := duck.DeepCopy()
+		duck.Spec.Version = "v1beta2"
+		if err := f.backing.Update(f.ctx, duck); err != nil {
+			t.Fatalf("unexpected err: %s", err)
+		}
+		// the index is only refreshed by events
+		expectVersion(t, f.client, "v1")
+
+		f.duckInformer.Update(old, duck)
+		expectVersion(t, f.client, "v1beta2")
+	})
+
+	t.Run("duck type not ready", func(t *testing.T) {
+		notReady := duckType.DeepCopy()
+		notReady.Status.Conditions[0].Status = metav1.ConditionFalse
+		f := setup(t, true, notReady, duckDeployment)
+		f.duckTypeInformer.Add(notReady)
+
+		expectErr(t, f.client, duckclient.ErrDuckTypeNotReady)
+	})
+
+	t.Run("ambiguous ducks", func(t *testing.T) {
+		f := setup(t, true, duckType, duckDeployment, readyDuck("deployments.v1.apps", "apps", "v1", "Deployment"))
+		f.duckTypeInformer.Add(duckType)
+
+		expectErr(t, f.client, duckclient.ErrUnknownDuck)
+	})
+}
+
+// indexManager runs the index against a fake cache
+type indexManager struct {
+	manager.Manager
+	cache     cache.Cache
+	runnables []manager.Runnable
+}
+
+func (m *indexManager) GetCache() cache.Cache {
+	return m.cache
+}
+
+func (m *indexManager) Add(r manager.Runnable) error {
+	m.runnables = append(m.runnables, r)
+	return nil
+}
+
+// indexCache serves informers from the fake informers and reads from the reader
+type indexCache struct {
+	*informertest.FakeInformers
+	client.Reader
+}
+
+func (c *indexCache) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	return c.Reader.Get(ctx, key, obj, opts...)
+}
+
+func (c *indexCache) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	return c.Reader.List(ctx, list, opts...)
+}
+
+// registeringInformer signals when a handler is added so events are not sent before the index is
+// listening
+type registeringInformer struct {
+	*controllertest.FakeInformer
+	once       sync.Once
+	registered chan struct{}
+}
+
+func newRegisteringInformer(synced bool) *registeringInformer {
+	opts := []controllertest.InformerOption{}
+	if synced {
+		opts = append(opts, controllertest.Synced)
+	}
+	return &registeringInformer{
+		FakeInformer: controllertest.NewFakeInformer(opts...),
+		registered:   make(chan struct{}),
+	}
+}
+
+func (i *registeringInformer) AddEventHandler(handler toolscache.ResourceEventHandler) (toolscache.ResourceEventHandlerRegistration, error) {
+	registration, err := i.FakeInformer.AddEventHandler(handler)
+	i.once.Do(func() {
+		close(i.registered)
+	})
+	return registration, err
+}
+
+func (i *registeringInformer) waitForHandler(t *testing.T) {
+	t.Helper()
+
+	select {
+	case <-i.registered:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for an event handler")
+	}
+}
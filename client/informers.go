@@ -0,0 +1,65 @@
+/*
+Copyright 2025 the original author or authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// informerKey identifies an informer in the broker's cache
+type informerKey struct {
+	gvk schema.GroupVersionKind
+	// the cache keeps separate informers for typed, unstructured and metadata objects
+	objType string
+}
+
+func newInformerKey(obj client.Object) informerKey {
+	return informerKey{
+		gvk:     obj.GetObjectKind().GroupVersionKind(),
+		objType: fmt.Sprintf("%T", obj),
+	}
+}
+
+// acquireInformer gets or starts the informer for the object in the broker's cache, counting the
+// duck informers using it. Must be called while holding the lock.
+func (b *broker) acquireInformer(ctx context.Context, obj client.Object) (cache.Informer, error) {
+	informer, err := b.cache.GetInformer(ctx, obj, cache.BlockUntilSynced(false))
+	if err != nil {
+		return nil, err
+	}
+	b.informerRefs[newInformerKey(obj)]++
+
+	return informer, nil
+}
+
+// releaseInformer releases the informer for the object, removing the informer from the broker's
+// cache once the last duck informer stops using it. Must be called while holding the lock.
+func (b *broker) releaseInformer(ctx context.Context, obj client.Object) error {
+	key := newInformerKey(obj)
+	if b.informerRefs[key] > 1 {
+		b.informerRefs[key]--
+		return nil
+	}
+	delete(b.informerRefs, key)
+
+	return b.cache.RemoveInformer(ctx, obj)
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2025 the original author or authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"reconciler.io/runtime/apis"
+	"reconciler.io/runtime/reconcilers"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	duckv1 "reconciler.io/ducks/api/v1"
+	duckclient "reconciler.io/ducks/client"
+	"reconciler.io/ducks/internal/testresources"
+)
+
+func TestListPagedWithCache(t *testing.T) {
+	namespace := "test-namespace"
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(duckv1.AddToScheme(scheme))
+	scheme.AddKnownTypeWithName(schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "ConditionDuck"}, &duckv1.Duck{})
+	scheme.AddKnownTypeWithName(schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "ConditionDuckList"}, &duckv1.DuckList{})
+
+	duckType := &duckv1.DuckType{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "conditionducks.example.com",
+		},
+		Spec: duckv1.DuckTypeSpec{
+			Group:  "example.com",
+			Plural: "conditionducks",
+			Kind:   "ConditionDuck",
+		},
+		Status: duckv1.DuckTypeStatus{
+			Status: apis.Status{
+				Conditions: []metav1.Condition{
+					{Type: duckv1.DuckTypeConditionReady, Status: metav1.ConditionTrue},
+				},
+			},
+		},
+	}
+
+	objs := []client.Object{
+		duckType,
+		readyDuck("deployments.apps", "apps", "v1", "Deployment"),
+		readyDuck("jobs.batch", "batch", "v1", "Job"),
+	}
+	for _, name := range []string{"blue", "green", "red"} {
+		objs = append(objs,
+			&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}},
+			&batchv1.Job{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}},
+		)
+	}
+	backing := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+
+	tests := map[string]func(ctx context.Context, c duckclient.Client, list client.ObjectList, opts ...client.ListOption) error{
+		"list": func(ctx context.Context, c duckclient.Client, list client.ObjectList, opts ...client.ListOption) error {
+			return c.List(ctx, list, opts...)
+		},
+		"track and list": func(ctx context.Context, c duckclient.Client, list client.ObjectList, opts ...client.ListOption) error {
+			return c.TrackAndList(ctx, list, opts...)
+		},
+	}
+	for name, list := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			c := duckclient.New(duckType.Name, reconcilers.Config{
+				Client:    &cacheLikeClient{Client: backing},
+				APIReader: &pagingReader{Reader: backing},
+			})
+
+			pages := [][]string{}
+			continueToken := ""
+			for range 10 {
+				page := &testresources.ConditionDuckList{}
+				if err := list(ctx, c, page, client.InNamespace(namespace), client.Limit(2), client.Continue(continueToken)); err != nil {
+					t.Fatalf("unexpected err: %s", err)
+				}
+				names := []string{}
+				for _, item := range page.Items {
+					names = append(names, fmt.Sprintf("%s/%s", item.Kind, item.Name))
+				}
+				pages = append(pages, names)
+				if continueToken = page.Continue; continueToken == "" {
+					break
+				}
+			}
+
+			expected := [][]string{
+				{"Deployment/blue", "Deployment/green"},
+				{"Deployment/red", "Job/blue"},
+				{"Job/green", "Job/red"},
+			}
+			if diff := cmp.Diff(expected, pages); diff != "" {
+				t.Errorf("unexpected pages (-expected, +actual): %s", diff)
+			}
+		})
+	}
+}
+
+// cacheLikeClient lists like the controller-runtime cache, continue tokens are rejected and a
+// sentinel continue token is always returned
+type cacheLikeClient struct {
+	client.Client
+}
+
+func (c *cacheLikeClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	listOpts := (&client.ListOptions{}).ApplyOptions(opts)
+	if listOpts.Continue != "" {
+		return errors.New("continue list option is not supported by the cache")
+	}
+	if err := c.Client.List(ctx, list, opts...); err != nil {
+		return err
+	}
+	if listOpts.Limit > 0 {
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return err
+		}
+		if err := meta.SetList(list, items[:min(int64(len(items)), listOpts.Limit)]); err != nil {
+			return err
+		}
+	}
+	list.SetContinue("continue-not-supported")
+	return nil
+}
+
+// pagingReader lists like the API server, honoring the limit and continue tokens
+type pagingReader struct {
+	client.Reader
+}
+
+func (r *pagingReader) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	listOpts := (&client.ListOptions{}).ApplyOptions(opts)
+	if err := r.Reader.List(ctx, list, opts...); err != nil {
+		return err
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return err
+	}
+	offset := 0
+	if listOpts.Continue != "" {
+		if offset, err = strconv.Atoi(listOpts.Continue); err != nil {
+			return err
+		}
+	}
+	end := len(items)
+	if listOpts.Limit > 0 && offset+int(listOpts.Limit) < end {
+		end = offset + int(listOpts.Limit)
+		list.SetContinue(strconv.Itoa(end))
+	}
+	return meta.SetList(list, items[offset:end])
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2025 the original author or authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	brokerEventsDelivered = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "ducks_broker_events_delivered_total",
+		Help: "Total number of events delivered to a broker subscriber",
+	}, []string{"broker", "subscriber"})
+	brokerEventsCoalesced = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "ducks_broker_events_coalesced_total",
+		Help: "Total number of events merged with a pending event for the same object",
+	}, []string{"broker", "subscriber"})
+	brokerEventsDropped = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "ducks_broker_events_dropped_total",
+		Help: "Total number of events dropped without being delivered to a broker subscriber",
+	}, []string{"broker", "subscriber"})
+	brokerDuckErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "ducks_broker_duck_errors_total",
+		Help: "Total number of ducks skipped by a broker because they could not be converted",
+	}, []string{"broker"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		brokerEventsDelivered,
+		brokerEventsCoalesced,
+		brokerEventsDropped,
+		brokerDuckErrors,
+	)
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2025 the original author or authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	toolscache "k8s.io/client-go/tools/cache"
+)
+
+// WithTransform applies the transform to each object received by the broker's duck informers,
+// after the object is pruned.
+func WithTransform(transform toolscache.TransformFunc) BrokerOption {
+	return func(b *broker) {
+		b.transform = transform
+	}
+}
+
+// WithPruneToDuckType prunes objects received by the broker's duck informers to the fields
+// declared by the DuckType's schema, plus the object's apiVersion, kind and metadata. Objects for
+// a DuckType without a schema, or before the DuckType is observed, are not pruned. The duck
+// informers are restarted when the schema changes. Managed fields are dropped unless
+// WithManagedFields is also set.
+func WithPruneToDuckType() BrokerOption {
+	return func(b *broker) {
+		b.prune = true
+	}
+}
+
+// WithManagedFields retains managed fields on objects transformed by the broker
+func WithManagedFields() BrokerOption {
+	return func(b *broker) {
+		b.keepManagedFields = true
+	}
+}
+
+// setSchema records the schema of the duck type for resources of the gvk
+func (b *broker) setSchema(gvk schema.GroupVersionKind, duckType schema.GroupKind, s *apiextensionsv1.JSONSchemaProps) {
+	b.schemaM.Lock()
+	defer b.schemaM.Unlock()
+
+	if b.schemas[gvk] == nil {
+		b.schemas[gvk] = map[schema.GroupKind]*apiextensionsv1.JSONSchemaProps{}
+	}
+	b.schemas[gvk][duckType] = s
+}
+
+// removeSchema forgets the schema of the duck type for resources of the gvk
+func (b *broker) removeSchema(gvk schema.GroupVersionKind, duckType schema.GroupKind) {
+	b.schemaM.Lock()
+	defer b.schemaM.Unlock()
+
+	delete(b.schemas[gvk], duckType)
+	if len(b.schemas[gvk]) == 0 {
+		delete(b.schemas, gvk)
+	}
+}
+
+// transforms is true if the broker's duck informers transform objects
+func (b *broker) transforms() bool {
+	return b.prune || b.transform != nil
+}
+
+// transformObject is the cache transform for the broker's duck informers
+func (b *broker) transformObject(obj interface{}) (interface{}, error) {
+	switch o := obj.(type) {
+	case *unstructured.Unstructured:
+		if b.prune {
+			b.schemaM.RLock()
+			schemas := b.schemas[o.GroupVersionKind()]
+			b.schemaM.RUnlock()
+			pruneObject(o.Object, schemas)
+		}
+		if !b.keepManagedFields {
+			o.SetManagedFields(nil)
+		}
+	case *metav1.PartialObjectMetadata:
+		if !b.keepManagedFields {
+			o.ManagedFields = nil
+		}
+	}
+
+	if b.transform != nil {
+		return b.transform(obj)
+	}
+	return obj, nil
+}
+
+// pruneObject removes fields from the object that are not declared by any of the schemas. The
+// apiVersion, kind and metadata are always retained. A nil schema retains every field.
+func pruneObject(obj map[string]interface{}, schemas map[schema.GroupKind]*apiextensionsv1.JSONSchemaProps) {
+	roots := make([]*apiextensionsv1.JSONSchemaProps, 0, len(schemas))
+	for _, s := range schemas {
+		if s == nil {
+			return
+		}
+		roots = append(roots, s)
+	}
+	if len(roots) == 0 {
+		return
+	}
+
+	retained := map[string]interface{}{}
+	for _, field := range []string{"apiVersion", "kind", "metadata"} {
+		if value, ok := obj[field]; ok {
+			retained[field] = value
+		}
+	}
+	prune(obj, roots)
+	for field, value := range retained {
+		obj[field] = value
+	}
+}
+
+// prune the value to the fields declared by the schemas. A nil schema retains the whole value.
+func prune(value interface{}, schemas []*apiextensionsv1.JSONSchemaProps) interface{} {
+	for _, s := range schemas {
+		if s == nil {
+			return value
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			childSchemas := []*apiextensionsv1.JSONSchemaProps{}
+			for _, s := range schemas {
+				if property, ok := s.Properties[key]; ok {
+					childSchemas = append(childSchemas, &property)
+				} else if s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil {
+					childSchemas = append(childSchemas, s.AdditionalProperties.Schema)
+				} else if s.AdditionalProperties != nil && s.AdditionalProperties.Allows {
+					childSchemas = append(childSchemas, nil)
+				} else if s.XPreserveUnknownFields != nil && *s.XPreserveUnknownFields {
+					childSchemas = append(childSchemas, nil)
+				}
+			}
+			if len(childSchemas) == 0 {
+				delete(v, key)
+				continue
+			}
+			v[key] = prune(child, childSchemas)
+		}
+		return v
+	case []interface{}:
+		itemSchemas := []*apiextensionsv1.JSONSchemaProps{}
+		for _, s := range schemas {
+			if s.Items == nil || s.Items.Schema == nil {
+				// the item shape is unknown
+				return v
+			}
+			itemSchemas = append(itemSchemas, s.Items.Schema)
+		}
+		for i := range v {
+			v[i] = prune(v[i], itemSchemas)
+		}
+		return v
+	default:
+		return v
+	}
+}
@@ -0,0 +1,254 @@
+/*
+Copyright 2025 the original author or authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+)
+
+func TestPruneObject(t *testing.T) {
+	conditionsSchema := &apiextensionsv1.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextensionsv1.JSONSchemaProps{
+			"status": {
+				Type: "object",
+				Properties: map[string]apiextensionsv1.JSONSchemaProps{
+					"conditions": {
+						Type: "array",
+						Items: &apiextensionsv1.JSONSchemaPropsOrArray{
+							Schema: &apiextensionsv1.JSONSchemaProps{
+								Type: "object",
+								Properties: map[string]apiextensionsv1.JSONSchemaProps{
+									"type":   {Type: "string"},
+									"status": {Type: "string"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	bindingSchema := &apiextensionsv1.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextensionsv1.JSONSchemaProps{
+			"status": {
+				Type: "object",
+				Properties: map[string]apiextensionsv1.JSONSchemaProps{
+					"binding": {
+						Type: "object",
+						Properties: map[string]apiextensionsv1.JSONSchemaProps{
+							"name": {Type: "string"},
+						},
+					},
+				},
+			},
+		},
+	}
+	object := func() map[string]interface{} {
+		return map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata": map[string]interface{}{
+				"name":   "my-deployment",
+				"labels": map[string]interface{}{"app": "blue"},
+			},
+			"spec": map[string]interface{}{
+				"replicas": int64(1),
+			},
+			"status": map[string]interface{}{
+				"replicas": int64(1),
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "Available", "status": "True", "reason": "MinimumReplicasAvailable"},
+				},
+				"binding": map[string]interface{}{"name": "my-secret", "namespace": "default"},
+			},
+		}
+	}
+	metadata := map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]interface{}{
+			"name":   "my-deployment",
+			"labels": map[string]interface{}{"app": "blue"},
+		},
+	}
+	withStatus := func(status map[string]interface{}) map[string]interface{} {
+		obj := map[string]interface{}{}
+		for k, v := range metadata {
+			obj[k] = v
+		}
+		obj["status"] = status
+		return obj
+	}
+
+	tests := map[string]struct {
+		schemas  map[schema.GroupKind]*apiextensionsv1.JSONSchemaProps
+		expected map[string]interface{}
+	}{
+		"no schemas": {
+			expected: object(),
+		},
+		"nil schema retains every field": {
+			schemas: map[schema.GroupKind]*apiextensionsv1.JSONSchemaProps{
+				{Group: "example.com", Kind: "ConditionDuck"}: conditionsSchema,
+				{Group: "example.com", Kind: "OtherDuck"}:     nil,
+			},
+			expected: object(),
+		},
+		"prunes undeclared fields": {
+			schemas: map[schema.GroupKind]*apiextensionsv1.JSONSchemaProps{
+				{Group: "example.com", Kind: "ConditionDuck"}: conditionsSchema,
+			},
+			expected: withStatus(map[string]interface{}{
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "Available", "status": "True"},
+				},
+			}),
+		},
+		"retains the union of schemas": {
+			schemas: map[schema.GroupKind]*apiextensionsv1.JSONSchemaProps{
+				{Group: "example.com", Kind: "ConditionDuck"}: conditionsSchema,
+				{Group: "example.com", Kind: "BindingDuck"}:   bindingSchema,
+			},
+			expected: withStatus(map[string]interface{}{
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "Available", "status": "True"},
+				},
+				"binding": map[string]interface{}{"name": "my-secret"},
+			}),
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual := object()
+			pruneObject(actual, tc.schemas)
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("unexpected object (-expected, +actual): %s", diff)
+			}
+		})
+	}
+}
+
+func TestPrune(t *testing.T) {
+	tests := map[string]struct {
+		value    interface{}
+		schemas  []*apiextensionsv1.JSONSchemaProps
+		expected interface{}
+	}{
+		"scalar": {
+			value:    "value",
+			schemas:  []*apiextensionsv1.JSONSchemaProps{{Type: "string"}},
+			expected: "value",
+		},
+		"undeclared properties": {
+			value: map[string]interface{}{"declared": "value", "undeclared": "value"},
+			schemas: []*apiextensionsv1.JSONSchemaProps{{
+				Type:       "object",
+				Properties: map[string]apiextensionsv1.JSONSchemaProps{"declared": {Type: "string"}},
+			}},
+			expected: map[string]interface{}{"declared": "value"},
+		},
+		"additional properties schema": {
+			value: map[string]interface{}{
+				"blue":  map[string]interface{}{"name": "blue", "extra": "value"},
+				"green": map[string]interface{}{"name": "green"},
+			},
+			schemas: []*apiextensionsv1.JSONSchemaProps{{
+				Type: "object",
+				AdditionalProperties: &apiextensionsv1.JSONSchemaPropsOrBool{
+					Schema: &apiextensionsv1.JSONSchemaProps{
+						Type:       "object",
+						Properties: map[string]apiextensionsv1.JSONSchemaProps{"name": {Type: "string"}},
+					},
+				},
+			}},
+			expected: map[string]interface{}{
+				"blue":  map[string]interface{}{"name": "blue"},
+				"green": map[string]interface{}{"name": "green"},
+			},
+		},
+		"additional properties allowed": {
+			value: map[string]interface{}{"any": map[string]interface{}{"nested": "value"}},
+			schemas: []*apiextensionsv1.JSONSchemaProps{{
+				Type:                 "object",
+				AdditionalProperties: &apiextensionsv1.JSONSchemaPropsOrBool{Allows: true},
+			}},
+			expected: map[string]interface{}{"any": map[string]interface{}{"nested": "value"}},
+		},
+		"preserve unknown fields": {
+			value: map[string]interface{}{"any": map[string]interface{}{"nested": "value"}},
+			schemas: []*apiextensionsv1.JSONSchemaProps{{
+				Type:                   "object",
+				XPreserveUnknownFields: ptr.To(true),
+			}},
+			expected: map[string]interface{}{"any": map[string]interface{}{"nested": "value"}},
+		},
+		"array items": {
+			value: []interface{}{
+				map[string]interface{}{"name": "blue", "extra": "value"},
+			},
+			schemas: []*apiextensionsv1.JSONSchemaProps{{
+				Type: "array",
+				Items: &apiextensionsv1.JSONSchemaPropsOrArray{
+					Schema: &apiextensionsv1.JSONSchemaProps{
+						Type:       "object",
+						Properties: map[string]apiextensionsv1.JSONSchemaProps{"name": {Type: "string"}},
+					},
+				},
+			}},
+			expected: []interface{}{
+				map[string]interface{}{"name": "blue"},
+			},
+		},
+		"array without an item schema": {
+			value: []interface{}{
+				map[string]interface{}{"name": "blue", "extra": "value"},
+			},
+			schemas: []*apiextensionsv1.JSONSchemaProps{{Type: "array"}},
+			expected: []interface{}{
+				map[string]interface{}{"name": "blue", "extra": "value"},
+			},
+		},
+		"nil schema": {
+			value:    map[string]interface{}{"any": "value"},
+			schemas:  []*apiextensionsv1.JSONSchemaProps{nil},
+			expected: map[string]interface{}{"any": "value"},
+		},
+		"property declared by either schema": {
+			value: map[string]interface{}{"blue": "value", "green": "value", "red": "value"},
+			schemas: []*apiextensionsv1.JSONSchemaProps{
+				{Type: "object", Properties: map[string]apiextensionsv1.JSONSchemaProps{"blue": {Type: "string"}}},
+				{Type: "object", Properties: map[string]apiextensionsv1.JSONSchemaProps{"green": {Type: "string"}}},
+			},
+			expected: map[string]interface{}{"blue": "value", "green": "value"},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual := prune(tc.value, tc.schemas)
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("unexpected value (-expected, +actual): %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2025 the original author or authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"reconciler.io/runtime/reconcilers"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// TypedClient reads resources implementing a duck type into the Go struct for the duck's shape.
+// T is the duck's type and L the list type for the duck, both must be pointers to structs.
+type TypedClient[T client.Object, L client.ObjectList] struct {
+	client Client
+}
+
+// NewTyped creates a typed client for resources implementing the named duck type
+func NewTyped[T client.Object, L client.ObjectList](duckType string, config reconcilers.Config, opts ...Option) *TypedClient[T, L] {
+	return NewTypedForClient[T, L](New(duckType, config, opts...))
+}
+
+// NewTypedForClient creates a typed client on top of an existing duck client
+func NewTypedForClient[T client.Object, L client.ObjectList](c Client) *TypedClient[T, L] {
+	return &TypedClient[T, L]{
+		client: c,
+	}
+}
+
+// Client returns the underlying duck client
+func (c *TypedClient[T, L]) Client() Client {
+	return c.client
+}
+
+// Get the resource for the implementer's GroupKind. The version of the gvk is resolved from the
+// duck and may be empty.
+func (c *TypedClient[T, L]) Get(ctx context.Context, gvk schema.GroupVersionKind, key client.ObjectKey, opts ...client.GetOption) (T, error) {
+	obj := newEmpty[T]()
+	obj.GetObjectKind().SetGroupVersionKind(gvk)
+	if err := c.client.Get(ctx, key, obj, opts...); err != nil {
+		var nilT T
+		return nilT, err
+	}
+	return obj, nil
+}
+
+// TrackAndGet the resource for the implementer's GroupKind. The version of the gvk is resolved
+// from the duck and may be empty.
+func (c *TypedClient[T, L]) TrackAndGet(ctx context.Context, gvk schema.GroupVersionKind, key client.ObjectKey, opts ...client.GetOption) (T, error) {
+	obj := newEmpty[T]()
+	obj.GetObjectKind().SetGroupVersionKind(gvk)
+	if err := c.client.TrackAndGet(ctx, key, obj, opts...); err != nil {
+		var nilT T
+		return nilT, err
+	}
+	return obj, nil
+}
+
+// ListAll resources implementing the duck type across all ducks
+func (c *TypedClient[T, L]) ListAll(ctx context.Context, opts ...client.ListOption) ([]T, error) {
+	list := newEmpty[L]()
+	listErr := c.client.List(ctx, list, opts...)
+	if listErr != nil && !isPartialListError(listErr) {
+		return nil, listErr
+	}
+	items, err := extractItems[T](list)
+	if err != nil {
+		return nil, err
+	}
+	// a partial list error, if any
+	return items, listErr
+}
+
+// TrackAndListAll resources implementing the duck type across all ducks
+func (c *TypedClient[T, L]) TrackAndListAll(ctx context.Context, opts ...client.ListOption) ([]T, error) {
+	list := newEmpty[L]()
+	listErr := c.client.TrackAndList(ctx, list, opts...)
+	if listErr != nil && !isPartialListError(listErr) {
+		return nil, listErr
+	}
+	items, err := extractItems[T](list)
+	if err != nil {
+		return nil, err
+	}
+	// a partial list error, if any
+	return items, listErr
+}
+
+func isPartialListError(err error) bool {
+	var partialErr *PartialListError
+	return errors.As(err, &partialErr)
+}
+
+func extractItems[T client.Object](list client.ObjectList) ([]T, error) {
+	objs, err := meta.ExtractList(list)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]T, len(objs))
+	for i, obj := range objs {
+		item, ok := obj.(T)
+		if !ok {
+			return nil, fmt.Errorf("list item %T is not a %T", obj, item)
+		}
+		items[i] = item
+	}
+	return items, nil
+}
+
+func newEmpty[T any]() T {
+	var nilT T
+	return reflect.New(reflect.TypeOf(nilT).Elem()).Interface().(T)
+}
@@ -0,0 +1,324 @@
+/*
+Copyright 2025 the original author or authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"reconciler.io/runtime/apis"
+	"reconciler.io/runtime/duck"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	duckv1 "reconciler.io/ducks/api/v1"
+)
+
+// duckWatch merges the watches of each ready duck into a single watch. Ducks are watched so that
+// upstream watches are added and removed as ducks become ready or are deleted.
+type duckWatch struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	watcher client.WithWatch
+	duckGK  schema.GroupKind
+	list    client.ObjectList
+	opts    []client.ListOption
+
+	result chan watch.Event
+
+	m        sync.Mutex
+	wg       sync.WaitGroup
+	stopped  bool
+	upstream map[string]*upstreamWatch
+}
+
+// upstreamWatch is the watch for a single duck
+type upstreamWatch struct {
+	gvk schema.GroupVersionKind
+	watch.Interface
+}
+
+var _ watch.Interface = (*duckWatch)(nil)
+
+func (c *duckClient) Watch(ctx context.Context, list client.ObjectList, opts ...client.ListOption) (watch.Interface, error) {
+	if c.watcher == nil {
+		return nil, ErrWatchNotSupported
+	}
+
+	duckGK := list.GetObjectKind().GroupVersionKind().GroupKind()
+	duckType, ducks, err := c.lookup(ctx, duckGK, false)
+	if err != nil {
+		return nil, err
+	}
+
+	duckList := &unstructured.UnstructuredList{}
+	duckList.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   duckType.Spec.Group,
+		Version: "v1",
+		Kind:    duckType.Spec.ListKind,
+	})
+	duckWatcher, err := c.watcher.Watch(ctx, duckList)
+	if err != nil {
+		return nil, err
+	}
+
+	listOpts := &client.ListOptions{}
+	listOpts.ApplyOptions(opts)
+	// resource versions are specific to each implementer, the upstream watches start from the
+	// current state
+	if listOpts.Raw != nil {
+		raw := listOpts.Raw.DeepCopy()
+		raw.ResourceVersion = ""
+		listOpts.Raw = raw
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	w := &duckWatch{
+		ctx:      ctx,
+		cancel:   cancel,
+		watcher:  c.watcher,
+		duckGK:   duckGK,
+		list:     list.DeepCopyObject().(client.ObjectList),
+		opts:     []client.ListOption{listOpts},
+		result:   make(chan watch.Event),
+		upstream: map[string]*upstreamWatch{},
+	}
+	for i := range ducks {
+		if err := w.add(&ducks[i]); err != nil {
+			w.Stop()
+			w.stopAll(duckWatcher)
+			return nil, err
+		}
+	}
+
+	w.wg.Add(1)
+	go w.watchDucks(duckWatcher)
+	go func() {
+		<-ctx.Done()
+		w.stopAll(duckWatcher)
+		w.wg.Wait()
+		close(w.result)
+	}()
+
+	return w, nil
+}
+
+func (w *duckWatch) stopAll(duckWatcher watch.Interface) {
+	w.m.Lock()
+	defer w.m.Unlock()
+
+	w.stopped = true
+	duckWatcher.Stop()
+	for name, upstream := range w.upstream {
+		upstream.Stop()
+		delete(w.upstream, name)
+	}
+}
+
+func (w *duckWatch) ResultChan() <-chan watch.Event {
+	return w.result
+}
+
+func (w *duckWatch) Stop() {
+	w.cancel()
+}
+
+func (w *duckWatch) matches(duck *duckv1.Duck) bool {
+	if w.duckGK.Empty() {
+		return true
+	}
+	if w.duckGK.Group != duck.Spec.Group {
+		return false
+	}
+	return w.duckGK.Kind == duck.Spec.Kind || w.duckGK.Kind == fmt.Sprintf("%sList", duck.Spec.Kind)
+}
+
+func (w *duckWatch) add(duck *duckv1.Duck) error {
+	w.m.Lock()
+	defer w.m.Unlock()
+
+	if w.stopped {
+		return nil
+	}
+	gvk := schema.GroupVersionKind{
+		Group:   duck.Spec.Group,
+		Version: duck.Spec.Version,
+		Kind:    fmt.Sprintf("%sList", duck.Spec.Kind),
+	}
+	if existing, ok := w.upstream[duck.Name]; ok {
+		if existing.gvk == gvk {
+			// already watching
+			return nil
+		}
+		// the duck now points at a different resource
+		delete(w.upstream, duck.Name)
+		existing.Stop()
+	}
+
+	var list client.ObjectList = &unstructured.UnstructuredList{}
+	if isMetadataList(w.list) {
+		list = &metav1.PartialObjectMetadataList{}
+	}
+	list.GetObjectKind().SetGroupVersionKind(gvk)
+	upstream, err := w.watcher.Watch(w.ctx, list, w.opts...)
+	if err != nil {
+		return err
+	}
+	w.upstream[duck.Name] = &upstreamWatch{gvk: gvk, Interface: upstream}
+
+	w.wg.Add(1)
+	go w.forward(duck.Name, upstream)
+
+	return nil
+}
+
+func (w *duckWatch) remove(name string) {
+	w.m.Lock()
+	defer w.m.Unlock()
+
+	if upstream, ok := w.upstream[name]; ok {
+		delete(w.upstream, name)
+		upstream.Stop()
+	}
+}
+
+// watchDucks reacts to ducks for the duck type changing
+func (w *duckWatch) watchDucks(duckWatcher watch.Interface) {
+	defer w.wg.Done()
+	// the aggregate watch is unable to continue without knowing which ducks to watch
+	defer w.Stop()
+
+	log := logr.FromContextOrDiscard(w.ctx)
+
+	for event := range duckWatcher.ResultChan() {
+		if event.Type == watch.Bookmark || event.Type == watch.Error {
+			continue
+		}
+		u, err := toUnstructured(event.Object)
+		if err != nil {
+			log.Error(err, "unable to convert duck")
+			continue
+		}
+		duck := &duckv1.Duck{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, duck); err != nil {
+			log.Error(err, "unable to convert duck", "duck", u.GetName())
+			continue
+		}
+		if !w.matches(duck) {
+			continue
+		}
+
+		switch event.Type {
+		case watch.Added, watch.Modified:
+			if ready := duck.GetConditionManager(w.ctx).GetCondition(duckv1.DuckConditionReady); !apis.ConditionIsTrue(ready) {
+				w.remove(duck.Name)
+				continue
+			}
+			if err := w.add(duck); err != nil {
+				log.Error(err, "unable to watch duck", "duck", duck.Name)
+			}
+		case watch.Deleted:
+			w.remove(duck.Name)
+		}
+	}
+}
+
+func (w *duckWatch) isCurrent(name string, upstream watch.Interface) bool {
+	w.m.Lock()
+	defer w.m.Unlock()
+
+	current, ok := w.upstream[name]
+	return ok && current.Interface == upstream
+}
+
+// forward converts events from an upstream watch to the duck shape and sends them to the result channel
+func (w *duckWatch) forward(name string, upstream watch.Interface) {
+	defer w.wg.Done()
+
+	log := logr.FromContextOrDiscard(w.ctx).WithValues("duck", name)
+
+	for event := range upstream.ResultChan() {
+		switch event.Type {
+		case watch.Bookmark:
+			// resource versions are not comparable across ducks
+			continue
+		case watch.Error:
+			// forward status as is
+		default:
+			obj, err := w.convert(event.Object)
+			if err != nil {
+				log.Error(err, "unable to convert object")
+				continue
+			}
+			event.Object = obj
+		}
+
+		select {
+		case w.result <- event:
+		case <-w.ctx.Done():
+			return
+		}
+	}
+
+	if w.isCurrent(name, upstream) {
+		// the upstream watch closed unexpectedly, close the aggregate watch so the caller can restart it
+		w.Stop()
+	}
+}
+
+// convert an object from an upstream watch into the same shape as items in the requested list
+func (w *duckWatch) convert(obj runtime.Object) (runtime.Object, error) {
+	if metadata, ok := obj.(*metav1.PartialObjectMetadata); ok && isMetadataList(w.list) {
+		// already in the requested shape
+		return metadata, nil
+	}
+	u, err := toUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	list := w.list.DeepCopyObject().(client.ObjectList)
+	if err := duck.Convert(&unstructured.UnstructuredList{Items: []unstructured.Unstructured{*u}}, list); err != nil {
+		return nil, err
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) != 1 {
+		return nil, fmt.Errorf("expected 1 item, found %d", len(items))
+	}
+	return items[0], nil
+}
+
+// toUnstructured returns the object as unstructured, converting typed objects
+func toUnstructured(obj runtime.Object) (*unstructured.Unstructured, error) {
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		return u, nil
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected object type %T: %w", obj, err)
+	}
+	return &unstructured.Unstructured{Object: content}, nil
+}
@@ -0,0 +1,389 @@
+/*
+Copyright 2025 the original author or authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"reconciler.io/runtime/apis"
+	"reconciler.io/runtime/reconcilers"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	duckv1 "reconciler.io/ducks/api/v1"
+	duckclient "reconciler.io/ducks/client"
+	"reconciler.io/ducks/internal/testresources"
+)
+
+func TestWatch(t *testing.T) {
+	namespace := "test-namespace"
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(duckv1.AddToScheme(scheme))
+	// ducks are served as the kind defined by the DuckType
+	scheme.AddKnownTypeWithName(schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "ConditionDuck"}, &duckv1.Duck{})
+	scheme.AddKnownTypeWithName(schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "ConditionDuckList"}, &duckv1.DuckList{})
+
+	duckType := &duckv1.DuckType{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "conditionducks.example.com",
+		},
+		Spec: duckv1.DuckTypeSpec{
+			Group:  "example.com",
+			Plural: "conditionducks",
+			Kind:   "ConditionDuck",
+		},
+		Status: duckv1.DuckTypeStatus{
+			Status: apis.Status{
+				Conditions: []metav1.Condition{
+					{Type: duckv1.DuckTypeConditionReady, Status: metav1.ConditionTrue},
+				},
+			},
+		},
+	}
+	duckDeployment := readyDuck("deployments.apps", "apps", "v1", "Deployment")
+	duckJob := readyDuck("jobs.batch", "batch", "v1", "Job")
+
+	deploymentGVK := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "DeploymentList"}
+	jobGVK := schema.GroupVersionKind{Group: "batch", Version: "v1", Kind: "JobList"}
+
+	deployment := func(name string) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		}
+	}
+	job := func(name string) *batchv1.Job {
+		return &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		}
+	}
+
+	setup := func(t *testing.T, objs ...client.Object) (context.Context, *recordingWatcher, watch.Interface) {
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+
+		given := make([]client.Object, len(objs))
+		for i := range objs {
+			given[i] = objs[i].DeepCopyObject().(client.Object)
+		}
+		watcher := &recordingWatcher{
+			WithWatch: fake.NewClientBuilder().WithScheme(scheme).WithObjects(given...).Build(),
+		}
+		c := duckclient.New(duckType.Name, reconcilers.Config{Client: watcher})
+		w, err := c.(client.WithWatch).Watch(ctx, &testresources.ConditionDuckList{}, client.InNamespace(namespace), &client.ListOptions{
+			Raw: &metav1.ListOptions{ResourceVersion: "999"},
+		})
+		if err != nil {
+			t.Fatalf("unexpected err: %s", err)
+		}
+		t.Cleanup(w.Stop)
+
+		return ctx, watcher, w
+	}
+
+	t.Run("fans in events from each duck", func(t *testing.T) {
+		ctx, watcher, w := setup(t, duckType, duckDeployment, duckJob)
+
+		if err := watcher.Create(ctx, deployment("blue")); err != nil {
+			t.Fatalf("unexpected err: %s", err)
+		}
+		expectWatchEvent(t, w, watch.Added, "blue")
+		if err := watcher.Create(ctx, job("green")); err != nil {
+			t.Fatalf("unexpected err: %s", err)
+		}
+		expectWatchEvent(t, w, watch.Added, "green")
+
+		for _, gvk := range []schema.GroupVersionKind{deploymentGVK, jobGVK} {
+			upstream := watcher.waitFor(t, gvk)
+			if upstream.opts.Namespace != namespace {
+				t.Errorf("expected %s watch in namespace %q, got %q", gvk.Kind, namespace, upstream.opts.Namespace)
+			}
+			if upstream.opts.Raw != nil && upstream.opts.Raw.ResourceVersion != "" {
+				t.Errorf("expected %s watch without a resource version, got %q", gvk.Kind, upstream.opts.Raw.ResourceVersion)
+			}
+		}
+	})
+
+	t.Run("follows ducks as they are added and removed", func(t *testing.T) {
+		ctx, watcher, w := setup(t, duckType, duckDeployment)
+
+		if err := watcher.Create(ctx, duckJob.DeepCopy()); err != nil {
+			t.Fatalf("unexpected err: %s", err)
+		}
+		jobs := watcher.waitFor(t, jobGVK)
+		if err := watcher.Create(ctx, job("blue")); err != nil {
+			t.Fatalf("unexpected err: %s", err)
+		}
+		expectWatchEvent(t, w, watch.Added, "blue")
+
+		if err := watcher.Delete(ctx, duckJob.DeepCopy()); err != nil {
+			t.Fatalf("unexpected err: %s", err)
+		}
+		jobs.waitForStop(t)
+		if err := watcher.Create(ctx, job("red")); err != nil {
+			t.Fatalf("unexpected err: %s", err)
+		}
+		if err := watcher.Create(ctx, deployment("green")); err != nil {
+			t.Fatalf("unexpected err: %s", err)
+		}
+		// the job is no longer watched
+		expectWatchEvent(t, w, watch.Added, "green")
+	})
+
+	t.Run("follows a duck changing version", func(t *testing.T) {
+		ctx, watcher, _ := setup(t, duckType, duckDeployment)
+
+		deploymentsV1 := watcher.waitFor(t, deploymentGVK)
+		duck := &duckv1.Duck{TypeMeta: duckDeployment.TypeMeta}
+		if err := watcher.Get(ctx, client.ObjectKeyFromObject(duckDeployment), duck); err != nil {
+			t.Fatalf("unexpected err: %s", err)
+		}
+		duck.Spec.Version = "v1beta2"
+		if err := watcher.Update(ctx, duck); err != nil {
+			t.Fatalf("unexpected err: %s", err)
+		}
+		watcher.waitFor(t, schema.GroupVersionKind{Group: "apps", Version: "v1beta2", Kind: "DeploymentList"})
+		deploymentsV1.waitForStop(t)
+	})
+
+	t.Run("closes when an upstream watch closes", func(t *testing.T) {
+		_, watcher, w := setup(t, duckType, duckDeployment, duckJob)
+
+		// simulate the API server ending the watch
+		watcher.waitFor(t, deploymentGVK).Interface.Stop()
+		expectWatchClosed(t, w)
+	})
+
+	t.Run("closes the result channel when stopped", func(t *testing.T) {
+		_, watcher, w := setup(t, duckType, duckDeployment, duckJob)
+
+		deployments := watcher.waitFor(t, deploymentGVK)
+		w.Stop()
+		expectWatchClosed(t, w)
+		deployments.waitForStop(t)
+	})
+}
+
+func TestWatchSupport(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(duckv1.AddToScheme(scheme))
+	scheme.AddKnownTypeWithName(schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "ConditionDuck"}, &duckv1.Duck{})
+	scheme.AddKnownTypeWithName(schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "ConditionDuckList"}, &duckv1.DuckList{})
+
+	duckType := &duckv1.DuckType{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "conditionducks.example.com",
+		},
+		Spec: duckv1.DuckTypeSpec{
+			Group:  "example.com",
+			Plural: "conditionducks",
+			Kind:   "ConditionDuck",
+		},
+		Status: duckv1.DuckTypeStatus{
+			Status: apis.Status{
+				Conditions: []metav1.Condition{
+					{Type: duckv1.DuckTypeConditionReady, Status: metav1.ConditionTrue},
+				},
+			},
+		},
+	}
+	backing := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(duckType, readyDuck("deployments.apps", "apps", "v1", "Deployment")).
+		Build()
+	// like the manager's client, watches are not supported
+	withoutWatch := struct{ client.Client }{Client: backing}
+
+	t.Run("not supported by the config's client", func(t *testing.T) {
+		c := duckclient.New(duckType.Name, reconcilers.Config{Client: withoutWatch})
+		if _, err := c.(client.WithWatch).Watch(context.Background(), &testresources.ConditionDuckList{}); !errors.Is(err, duckclient.ErrWatchNotSupported) {
+			t.Errorf("expected ErrWatchNotSupported, got %v", err)
+		}
+	})
+
+	t.Run("watches with the watcher option", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		watcher := &recordingWatcher{WithWatch: backing}
+		c := duckclient.New(duckType.Name, reconcilers.Config{Client: withoutWatch}, duckclient.WithWatcher(watcher))
+		w, err := c.(client.WithWatch).Watch(ctx, &testresources.ConditionDuckList{})
+		if err != nil {
+			t.Fatalf("unexpected err: %s", err)
+		}
+		defer w.Stop()
+
+		watcher.waitFor(t, schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "DeploymentList"})
+		if err := backing.Create(ctx, &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "blue"}}); err != nil {
+			t.Fatalf("unexpected err: %s", err)
+		}
+		expectWatchEvent(t, w, watch.Added, "blue")
+	})
+}
+
+func readyDuck(name, group, version, kind string) *duckv1.Duck {
+	return &duckv1.Duck{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "example.com/v1",
+			Kind:       "ConditionDuck",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: duckv1.DuckSpec{
+			Group:   group,
+			Version: version,
+			Kind:    kind,
+		},
+		Status: duckv1.DuckStatus{
+			Status: apis.Status{
+				Conditions: []metav1.Condition{
+					{Type: duckv1.DuckConditionReady, Status: metav1.ConditionTrue},
+				},
+			},
+		},
+	}
+}
+
+func expectWatchEvent(t *testing.T, w watch.Interface, eventType watch.EventType, name string) {
+	t.Helper()
+
+	select {
+	case event, ok := <-w.ResultChan():
+		if !ok {
+			t.Fatalf("expected %s event for %q, watch closed", eventType, name)
+		}
+		obj, ok := event.Object.(*testresources.ConditionDuck)
+		if !ok {
+			t.Fatalf("expected a ConditionDuck, got %T", event.Object)
+		}
+		if event.Type != eventType || obj.Name != name {
+			t.Errorf("expected %s event for %q, got %s event for %q", eventType, name, event.Type, obj.Name)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for %s event for %q", eventType, name)
+	}
+}
+
+func expectWatchClosed(t *testing.T, w watch.Interface) {
+	t.Helper()
+
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case _, ok := <-w.ResultChan():
+			if !ok {
+				return
+			}
+		case <-timeout:
+			t.Fatalf("timed out waiting for the watch to close")
+		}
+	}
+}
+
+// recordingWatcher records the watches started on the client
+type recordingWatcher struct {
+	client.WithWatch
+
+	m       sync.Mutex
+	watches []*recordedWatch
+}
+
+type recordedWatch struct {
+	watch.Interface
+	gvk     schema.GroupVersionKind
+	opts    *client.ListOptions
+	stopped chan struct{}
+	once    sync.Once
+}
+
+func (w *recordedWatch) Stop() {
+	w.once.Do(func() {
+		close(w.stopped)
+	})
+	w.Interface.Stop()
+}
+
+func (w *recordedWatch) waitForStop(t *testing.T) {
+	t.Helper()
+
+	select {
+	case <-w.stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for the %s watch to stop", w.gvk)
+	}
+}
+
+func (c *recordingWatcher) Watch(ctx context.Context, list client.ObjectList, opts ...client.ListOption) (watch.Interface, error) {
+	gvk := list.GetObjectKind().GroupVersionKind()
+	upstream, err := c.WithWatch.Watch(ctx, list, opts...)
+	if err != nil {
+		return nil, err
+	}
+	listOpts := &client.ListOptions{}
+	listOpts.ApplyOptions(opts)
+	w := &recordedWatch{
+		Interface: upstream,
+		gvk:       gvk,
+		opts:      listOpts,
+		stopped:   make(chan struct{}),
+	}
+
+	c.m.Lock()
+	defer c.m.Unlock()
+	c.watches = append(c.watches, w)
+
+	return w, nil
+}
+
+// waitFor returns the most recent watch for the gvk, waiting for the watch to start
+func (c *recordingWatcher) waitFor(t *testing.T, gvk schema.GroupVersionKind) *recordedWatch {
+	t.Helper()
+
+	timeout := time.After(5 * time.Second)
+	for {
+		c.m.Lock()
+		for i := len(c.watches) - 1; i >= 0; i-- {
+			if c.watches[i].gvk == gvk {
+				w := c.watches[i]
+				c.m.Unlock()
+				return w
+			}
+		}
+		c.m.Unlock()
+
+		select {
+		case <-time.After(10 * time.Millisecond):
+		case <-timeout:
+			t.Fatalf("timed out waiting for a %s watch", gvk)
+		}
+	}
+}
@@ -18,7 +18,9 @@ package controller
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"maps"
 	"slices"
 	"strings"
 	"time"
@@ -33,6 +35,7 @@ import (
 	"reconciler.io/runtime/reconcilers"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	duckv1 "reconciler.io/ducks/api/v1"
 	duckreconcilers "reconciler.io/ducks/reconcilers"
@@ -150,7 +153,8 @@ func DuckCustomResourceDefinitionChildReconciler() reconcilers.SubReconciler[*du
 		DesiredChild: func(ctx context.Context, resource *duckv1.DuckType) (*apiextensionsv1.CustomResourceDefinition, error) {
 			child := &apiextensionsv1.CustomResourceDefinition{
 				ObjectMeta: metav1.ObjectMeta{
-					Name: resource.Name,
+					Name:        resource.Name,
+					Annotations: map[string]string{},
 				},
 				Spec: apiextensionsv1.CustomResourceDefinitionSpec{
 					Group: resource.Spec.Group,
@@ -303,11 +307,25 @@ func DuckCustomResourceDefinitionChildReconciler() reconcilers.SubReconciler[*du
 				},
 			}
 
+			if resource.Spec.Schema != nil {
+				// record the shape of the duck type for tooling, the schema describes implementers not the duck resource itself
+				schema, err := json.Marshal(resource.Spec.Schema)
+				if err != nil {
+					return nil, err
+				}
+				child.Annotations[duckv1.DuckTypeSchemaAnnotation] = string(schema)
+			}
+
 			return child, nil
 		},
 		ChildObjectManager: &reconcilers.UpdatingObjectManager[*apiextensionsv1.CustomResourceDefinition]{
 			MergeBeforeUpdate: func(current, desired *apiextensionsv1.CustomResourceDefinition) {
 				current.Labels = desired.Labels
+				if schema, ok := desired.Annotations[duckv1.DuckTypeSchemaAnnotation]; ok {
+					metav1.SetMetaDataAnnotation(&current.ObjectMeta, duckv1.DuckTypeSchemaAnnotation, schema)
+				} else {
+					delete(current.Annotations, duckv1.DuckTypeSchemaAnnotation)
+				}
 				current.Spec = desired.Spec
 			},
 		},
@@ -377,6 +395,13 @@ func DuckSubReconciler() reconcilers.SubReconciler[*duckv1.DuckType] {
 
 			return nil
 		},
+		SubManagerStatus: func(ctx context.Context, resource *duckv1.DuckType, err error) {
+			if err != nil {
+				resource.GetConditionManager(ctx).MarkFalse(duckv1.DuckTypeConditionDuckController, "Failed", "%s", err)
+				return
+			}
+			resource.GetConditionManager(ctx).MarkTrue(duckv1.DuckTypeConditionDuckController, "Running", "")
+		},
 	}
 }
 
@@ -516,6 +541,7 @@ func DuckReconcilerReadyCheck() reconcilers.SubReconciler[*duckv1.Duck] {
 		Setup: func(ctx context.Context, mgr ctrl.Manager, bldr *builder.Builder) error {
 			bldr.Watches(&apiextensionsv1.CustomResourceDefinition{}, reconcilers.EnqueueTracked(ctx))
 			bldr.Watches(&apiregistrationv1.APIService{}, reconcilers.EnqueueTracked(ctx))
+			bldr.Watches(&duckv1.DuckType{}, reconcilers.EnqueueTracked(ctx))
 
 			return nil
 		},
@@ -548,7 +574,7 @@ func DuckReconcilerReadyCheck() reconcilers.SubReconciler[*duckv1.Duck] {
 					}
 
 					resource.GetConditionManager(ctx).MarkTrue(duckv1.DuckConditionAvailable, "Available", "")
-					return nil
+					return checkDuckConformance(ctx, resource)
 				}
 			}
 
@@ -557,3 +583,140 @@ func DuckReconcilerReadyCheck() reconcilers.SubReconciler[*duckv1.Duck] {
 		},
 	}
 }
+
+// checkDuckConformance compares the schema of the CustomResourceDefinition backing the duck with
+// the schema declared by the DuckType
+func checkDuckConformance(ctx context.Context, resource *duckv1.Duck) error {
+	c := reconcilers.RetrieveConfigOrDie(ctx)
+
+	gvk, err := c.GroupVersionKindFor(resource)
+	if err != nil {
+		return err
+	}
+	mapping, err := c.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return err
+	}
+
+	duckType := &duckv1.DuckType{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: mapping.Resource.GroupResource().String(),
+		},
+	}
+	if err := c.TrackAndGet(ctx, client.ObjectKeyFromObject(duckType), duckType); err != nil {
+		if !apierrs.IsNotFound(err) {
+			return err
+		}
+		duckType = nil
+	}
+	if duckType == nil || duckType.Spec.Schema == nil {
+		// nothing to conform to
+		resource.GetConditionManager(ctx).MarkTrue(duckv1.DuckConditionConformant, "Conformant", "")
+		return nil
+	}
+
+	gvr := resource.GroupVersionResource()
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	if err := c.Get(ctx, client.ObjectKey{Name: gvr.GroupResource().String()}, crd); err != nil {
+		if apierrs.IsNotFound(err) {
+			// built-in and aggregated APIs are not backed by a CustomResourceDefinition
+			resource.GetConditionManager(ctx).MarkTrue(duckv1.DuckConditionConformant, "Unverified", "resource is not backed by a CustomResourceDefinition")
+			return nil
+		}
+		return err
+	}
+
+	idx := slices.IndexFunc(crd.Spec.Versions, func(v apiextensionsv1.CustomResourceDefinitionVersion) bool {
+		return v.Name == resource.Spec.Version
+	})
+	if idx < 0 {
+		resource.GetConditionManager(ctx).MarkFalse(duckv1.DuckConditionConformant, "VersionNotFound", "CustomResourceDefinition %s does not define version %q", crd.Name, resource.Spec.Version)
+		return nil
+	}
+	version := crd.Spec.Versions[idx]
+	if version.Schema == nil || version.Schema.OpenAPIV3Schema == nil {
+		resource.GetConditionManager(ctx).MarkTrue(duckv1.DuckConditionConformant, "Unverified", "CustomResourceDefinition %s does not define a schema for version %q", crd.Name, resource.Spec.Version)
+		return nil
+	}
+
+	missing, incompatible := compareSchema("", duckType.Spec.Schema, version.Schema.OpenAPIV3Schema)
+	if len(missing) != 0 || len(incompatible) != 0 {
+		problems := []string{}
+		if len(missing) != 0 {
+			problems = append(problems, fmt.Sprintf("missing fields: %s", strings.Join(missing, ", ")))
+		}
+		if len(incompatible) != 0 {
+			problems = append(problems, fmt.Sprintf("incompatible fields: %s", strings.Join(incompatible, ", ")))
+		}
+		resource.GetConditionManager(ctx).MarkFalse(duckv1.DuckConditionConformant, "NotConformant", "%s", strings.Join(problems, "; "))
+		return nil
+	}
+
+	resource.GetConditionManager(ctx).MarkTrue(duckv1.DuckConditionConformant, "Conformant", "")
+	return nil
+}
+
+// compareSchema walks the duck schema returning the paths for fields that are missing from the
+// implementer's schema and fields whose type is incompatible with the duck's declared type. A
+// field the duck requires is incompatible when the implementer declares it as optional.
+func compareSchema(path string, duck, impl *apiextensionsv1.JSONSchemaProps) (missing []string, incompatible []string) {
+	if duck == nil || impl == nil {
+		return nil, nil
+	}
+
+	duckType, implType := schemaType(duck), schemaType(impl)
+	if duckType != "" && implType != "" && duckType != implType && (duckType != "number" || implType != "integer") {
+		fieldPath := path
+		if fieldPath == "" {
+			fieldPath = "."
+		}
+		return nil, []string{fmt.Sprintf("%s (expected %s, found %s)", fieldPath, duckType, implType)}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(duck.Properties)) {
+		if path == "" && (name == "apiVersion" || name == "kind" || name == "metadata") {
+			// common to all resources
+			continue
+		}
+		fieldPath := fmt.Sprintf("%s.%s", path, name)
+		duckProp := duck.Properties[name]
+		implProp, ok := impl.Properties[name]
+		if !ok {
+			if impl.AdditionalProperties != nil && impl.AdditionalProperties.Schema != nil {
+				implProp, ok = *impl.AdditionalProperties.Schema, true
+			} else if ptr.Deref(impl.XPreserveUnknownFields, false) || (impl.AdditionalProperties != nil && impl.AdditionalProperties.Allows) {
+				// any value is accepted
+				continue
+			}
+		}
+		if !ok {
+			missing = append(missing, fieldPath)
+			continue
+		}
+		if slices.Contains(duck.Required, name) && !slices.Contains(impl.Required, name) {
+			incompatible = append(incompatible, fmt.Sprintf("%s (required, found optional)", fieldPath))
+		}
+		m, i := compareSchema(fieldPath, &duckProp, &implProp)
+		missing = append(missing, m...)
+		incompatible = append(incompatible, i...)
+	}
+	if duck.Items != nil && impl.Items != nil {
+		m, i := compareSchema(fmt.Sprintf("%s[]", path), duck.Items.Schema, impl.Items.Schema)
+		missing = append(missing, m...)
+		incompatible = append(incompatible, i...)
+	}
+	if duck.AdditionalProperties != nil && impl.AdditionalProperties != nil {
+		m, i := compareSchema(fmt.Sprintf("%s[*]", path), duck.AdditionalProperties.Schema, impl.AdditionalProperties.Schema)
+		missing = append(missing, m...)
+		incompatible = append(incompatible, i...)
+	}
+
+	return missing, incompatible
+}
+
+func schemaType(s *apiextensionsv1.JSONSchemaProps) string {
+	if s.XIntOrString {
+		return "int-or-string"
+	}
+	return s.Type
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"testing"
 
+	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -76,6 +77,10 @@ func TestDuckTypeReconciler(t *testing.T) {
 				d.True()
 				d.Reason("Defined")
 			})
+			d.ConditionDie(ducksv1.DuckTypeConditionDuckController, func(d *diemetav1.ConditionDie) {
+				d.True()
+				d.Reason("Running")
+			})
 			d.ConditionDie(ducksv1.DuckTypeConditionReady, func(d *diemetav1.ConditionDie) {
 				d.True()
 				d.Reason("Ready")
@@ -171,6 +176,32 @@ func TestDuckTypeReconciler(t *testing.T) {
 				editClusterRoleGiven,
 			},
 		},
+		"records schema on crd": {
+			Request: request,
+			StatusSubResourceTypes: []client.Object{
+				&ducksv1.DuckType{},
+			},
+			GivenObjects: []client.Object{
+				given.
+					SpecDie(func(d *ducksv1.DuckTypeSpecDie) {
+						d.Schema(&apiextensionsv1.JSONSchemaProps{
+							Type: "object",
+						})
+					}),
+				crdGiven,
+				viewClusterRoleGiven,
+				editClusterRoleGiven,
+			},
+			ExpectEvents: []rtesting.Event{
+				rtesting.NewEvent(given, scheme, corev1.EventTypeNormal, "Updated", "Updated CustomResourceDefinition %q", name),
+			},
+			ExpectUpdates: []client.Object{
+				crdGiven.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.AddAnnotation(ducksv1.DuckTypeSchemaAnnotation, `{"type":"object"}`)
+					}),
+			},
+		},
 	}
 
 	rts.Run(t, scheme, func(t *testing.T, tc *rtesting.ReconcilerTestCase, c reconcilers.Config) reconcile.Reconciler {
@@ -255,6 +286,10 @@ func TestDuckReconciler(t *testing.T) {
 				d.True()
 				d.Reason("Defined")
 			})
+			d.ConditionDie(ducksv1.DuckConditionConformant, func(d *diemetav1.ConditionDie) {
+				d.True()
+				d.Reason("Conformant")
+			})
 			d.ConditionDie(ducksv1.DuckConditionReady, func(d *diemetav1.ConditionDie) {
 				d.True()
 				d.Reason("Ready")
@@ -294,6 +329,67 @@ func TestDuckReconciler(t *testing.T) {
 				AddVerbs("get", "list", "watch", "patch"),
 		)
 
+	duckTypeGiven := ducksv1.DuckTypeBlank.
+		MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+			d.Name("ducks.example.com")
+			d.CreationTimestamp(now)
+			d.Generation(1)
+		}).
+		SpecDie(func(d *ducksv1.DuckTypeSpecDie) {
+			d.Group("example.com")
+			d.Plural("ducks")
+			d.Kind("Duck")
+			d.Schema(&apiextensionsv1.JSONSchemaProps{
+				Type: "object",
+				Properties: map[string]apiextensionsv1.JSONSchemaProps{
+					"spec": {
+						Type: "object",
+						Properties: map[string]apiextensionsv1.JSONSchemaProps{
+							"color": {Type: "string"},
+							"size":  {Type: "integer"},
+						},
+					},
+				},
+			})
+		})
+
+	crdGiven := &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "duckinstances.example.com",
+			CreationTimestamp: now,
+		},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: "example.com",
+			Names: apiextensionsv1.CustomResourceDefinitionNames{
+				Plural: "duckinstances",
+				Kind:   "DuckInstance",
+			},
+			Scope: apiextensionsv1.NamespaceScoped,
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+				{
+					Name:    "v1",
+					Served:  true,
+					Storage: true,
+					Schema: &apiextensionsv1.CustomResourceValidation{
+						OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+							Type: "object",
+							Properties: map[string]apiextensionsv1.JSONSchemaProps{
+								"spec": {
+									Type: "object",
+									Properties: map[string]apiextensionsv1.JSONSchemaProps{
+										"color": {Type: "string"},
+										"size":  {Type: "integer"},
+										"shape": {Type: "string"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
 	rts := rtesting.ReconcilerTests{
 		"in sync": {
 			Request: request,
@@ -311,6 +407,130 @@ func TestDuckReconciler(t *testing.T) {
 			ExpectTracks: []rtesting.TrackRequest{
 				rtesting.NewTrackRequest(&apiextensionsv1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "duckinstances.example.com"}}, given, scheme),
 				rtesting.NewTrackRequest(&apiregistrationv1.APIService{ObjectMeta: metav1.ObjectMeta{Name: "v1.example.com"}}, given, scheme),
+				rtesting.NewTrackRequest(&ducksv1.DuckType{ObjectMeta: metav1.ObjectMeta{Name: "ducks.example.com"}}, given, scheme),
+			},
+		},
+		"conformant": {
+			Request: request,
+			StatusSubResourceTypes: []client.Object{
+				&ducksv1.Duck{
+					TypeMeta: duckMeta,
+				},
+			},
+			GivenAPIResources: givenAPIResources,
+			GivenObjects: []client.Object{
+				given,
+				duckTypeGiven,
+				crdGiven,
+				viewClusterRoleGiven,
+				editClusterRoleGiven,
+			},
+			ExpectTracks: []rtesting.TrackRequest{
+				rtesting.NewTrackRequest(&apiextensionsv1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "duckinstances.example.com"}}, given, scheme),
+				rtesting.NewTrackRequest(&apiregistrationv1.APIService{ObjectMeta: metav1.ObjectMeta{Name: "v1.example.com"}}, given, scheme),
+				rtesting.NewTrackRequest(&ducksv1.DuckType{ObjectMeta: metav1.ObjectMeta{Name: "ducks.example.com"}}, given, scheme),
+			},
+		},
+		"not conformant": {
+			Request: request,
+			StatusSubResourceTypes: []client.Object{
+				&ducksv1.Duck{
+					TypeMeta: duckMeta,
+				},
+			},
+			GivenAPIResources: givenAPIResources,
+			GivenObjects: []client.Object{
+				given,
+				duckTypeGiven,
+				func() *apiextensionsv1.CustomResourceDefinition {
+					crd := crdGiven.DeepCopy()
+					crd.Spec.Versions[0].Schema.OpenAPIV3Schema.Properties["spec"] = apiextensionsv1.JSONSchemaProps{
+						Type: "object",
+						Properties: map[string]apiextensionsv1.JSONSchemaProps{
+							"size": {Type: "string"},
+						},
+					}
+					return crd
+				}(),
+				viewClusterRoleGiven,
+				editClusterRoleGiven,
+			},
+			ExpectTracks: []rtesting.TrackRequest{
+				rtesting.NewTrackRequest(&apiextensionsv1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "duckinstances.example.com"}}, given, scheme),
+				rtesting.NewTrackRequest(&apiregistrationv1.APIService{ObjectMeta: metav1.ObjectMeta{Name: "v1.example.com"}}, given, scheme),
+				rtesting.NewTrackRequest(&ducksv1.DuckType{ObjectMeta: metav1.ObjectMeta{Name: "ducks.example.com"}}, given, scheme),
+			},
+			ExpectEvents: []rtesting.Event{
+				rtesting.NewEvent(given, scheme, corev1.EventTypeNormal, "StatusUpdated", "Updated status"),
+			},
+			ExpectStatusUpdates: []client.Object{
+				given.
+					StatusDie(func(d *ducksv1.DuckStatusDie) {
+						d.ConditionDie(ducksv1.DuckConditionConformant, func(d *diemetav1.ConditionDie) {
+							d.False()
+							d.Reason("NotConformant")
+							d.Message("missing fields: .spec.color; incompatible fields: .spec.size (expected integer, found string)")
+						})
+						d.ConditionDie(ducksv1.DuckConditionReady, func(d *diemetav1.ConditionDie) {
+							d.False()
+							d.Reason("NotConformant")
+							d.Message("missing fields: .spec.color; incompatible fields: .spec.size (expected integer, found string)")
+						})
+					}),
+			},
+		},
+		"not conformant optional field": {
+			Request: request,
+			StatusSubResourceTypes: []client.Object{
+				&ducksv1.Duck{
+					TypeMeta: duckMeta,
+				},
+			},
+			GivenAPIResources: givenAPIResources,
+			GivenObjects: []client.Object{
+				given,
+				duckTypeGiven.
+					SpecDie(func(d *ducksv1.DuckTypeSpecDie) {
+						d.Schema(&apiextensionsv1.JSONSchemaProps{
+							Type: "object",
+							Properties: map[string]apiextensionsv1.JSONSchemaProps{
+								"spec": {
+									Type:     "object",
+									Required: []string{"color"},
+									Properties: map[string]apiextensionsv1.JSONSchemaProps{
+										"color": {Type: "string"},
+										"size":  {Type: "integer"},
+									},
+								},
+							},
+						})
+					}),
+				crdGiven,
+				viewClusterRoleGiven,
+				editClusterRoleGiven,
+			},
+			ExpectTracks: []rtesting.TrackRequest{
+				rtesting.NewTrackRequest(&apiextensionsv1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "duckinstances.example.com"}}, given, scheme),
+				rtesting.NewTrackRequest(&apiregistrationv1.APIService{ObjectMeta: metav1.ObjectMeta{Name: "v1.example.com"}}, given, scheme),
+				rtesting.NewTrackRequest(&ducksv1.DuckType{ObjectMeta: metav1.ObjectMeta{Name: "ducks.example.com"}}, given, scheme),
+			},
+			ExpectEvents: []rtesting.Event{
+				rtesting.NewEvent(given, scheme, corev1.EventTypeNormal, "StatusUpdated", "Updated status"),
+			},
+			ExpectStatusUpdates: []client.Object{
+				given.
+					StatusDie(func(d *ducksv1.DuckStatusDie) {
+						d.ConditionDie(ducksv1.DuckConditionConformant, func(d *diemetav1.ConditionDie) {
+							d.False()
+							d.Reason("NotConformant")
+							d.Message("incompatible fields: .spec.color (required, found optional)")
+						})
+						d.ConditionDie(ducksv1.DuckConditionReady, func(d *diemetav1.ConditionDie) {
+							d.False()
+							d.Reason("NotConformant")
+							d.Message("incompatible fields: .spec.color (required, found optional)")
+						})
+					}),
 			},
 		},
 	}
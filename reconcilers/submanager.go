@@ -26,14 +26,19 @@ import (
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/workqueue"
 	"k8s.io/utils/ptr"
 	"reconciler.io/runtime/reconcilers"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	"reconciler.io/ducks/internal/submanager"
 )
@@ -59,14 +64,102 @@ type SubManagerReconciler[Type client.Object] struct {
 	LocalTypes          func(ctx context.Context, resource Type) ([]schema.GroupKind, error)
 	SetupWithSubManager func(ctx context.Context, mgr ctrl.Manager, resource Type) error
 
+	// SubManagerStatus reflects the state of the resource's submanager on the resource, commonly
+	// as a condition. The error is nil while the submanager is running, otherwise it describes why
+	// the submanager failed or could not be started, including errors from LocalTypes.
+	//
+	// +optional
+	SubManagerStatus func(ctx context.Context, resource Type, err error)
+
+	// RestartBackoff delays restarting a submanager that failed. Defaults to an exponential
+	// backoff from one second up to five minutes. The backoff is reset once a submanager's cache
+	// syncs.
+	//
+	// +optional
+	RestartBackoff workqueue.TypedRateLimiter[types.UID]
+
 	initOnce sync.Once
 	mgr      ctrl.Manager
-	managers map[types.UID]subManagerEntry
+	// ctx outlives individual reconciles, submanagers are stopped when it is canceled as the
+	// manager shuts down
+	ctx    context.Context
+	cancel context.CancelFunc
+	// failures enqueues the resource for a submanager that failed
+	failures chan event.GenericEvent
+
+	// m guards managers and locks, it is only held while reading or writing the maps
+	m        sync.Mutex
+	managers map[types.UID]*subManagerEntry
+	locks    map[types.UID]*uidLock
+}
+
+// uidLock serializes reconciles for a resource, refs counts the reconciles holding or waiting for
+// the lock
+type uidLock struct {
+	sync.Mutex
+	refs int
 }
 
 type subManagerEntry struct {
-	done   <-chan error
+	// done is closed once the submanager stops
+	done   <-chan struct{}
 	cancel context.CancelFunc
+	// localTypes the submanager was started with
+	localTypes sets.Set[schema.GroupKind]
+
+	m sync.Mutex
+	// stopping is true once the submanager is asked to stop
+	stopping bool
+	// err returned by the submanager when it stopped
+	err error
+	// failed is true if the submanager stopped without being asked to stop
+	failed bool
+	// restartAt is the earliest time a failed submanager may be restarted
+	restartAt time.Time
+}
+
+// stop asks the submanager to stop
+func (e *subManagerEntry) stop() {
+	e.m.Lock()
+	e.stopping = true
+	e.m.Unlock()
+
+	e.cancel()
+}
+
+func (e *subManagerEntry) isStopping() bool {
+	e.m.Lock()
+	defer e.m.Unlock()
+
+	return e.stopping
+}
+
+// isDone is true once the submanager has stopped
+func (e *subManagerEntry) isDone() bool {
+	select {
+	case <-e.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (e *subManagerEntry) stopped(err error, failed bool, restartAt time.Time) {
+	e.m.Lock()
+	defer e.m.Unlock()
+
+	e.err = err
+	e.failed = failed
+	e.restartAt = restartAt
+}
+
+// result returns whether the submanager failed, when a failed submanager may be restarted and the
+// error the submanager stopped with
+func (e *subManagerEntry) result() (failed bool, restartAt time.Time, err error) {
+	e.m.Lock()
+	defer e.m.Unlock()
+
+	return e.failed, e.restartAt, e.err
 }
 
 func (r *SubManagerReconciler[T]) SetupWithManager(ctx context.Context, mgr ctrl.Manager, bldr *builder.Builder) error {
@@ -82,6 +175,16 @@ func (r *SubManagerReconciler[T]) SetupWithManager(ctx context.Context, mgr ctrl
 	if err := r.Validate(ctx); err != nil {
 		return err
 	}
+	// reprocess the resource when its submanager fails
+	bldr.WatchesRawSource(source.Channel(r.failures, &handler.EnqueueRequestForObject{}))
+	// stop the submanagers with the manager
+	if err := mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+		<-ctx.Done()
+		r.cancel()
+		return nil
+	})); err != nil {
+		return err
+	}
 	if r.Setup == nil {
 		return nil
 	}
@@ -101,7 +204,14 @@ func (r *SubManagerReconciler[T]) init() {
 			panic("SubManagerReconciler: SetupWithManager must be called before Reconcile")
 		}
 
-		r.managers = map[types.UID]subManagerEntry{}
+		if r.RestartBackoff == nil {
+			r.RestartBackoff = workqueue.NewTypedItemExponentialFailureRateLimiter[types.UID](time.Second, 5*time.Minute)
+		}
+
+		r.ctx, r.cancel = context.WithCancel(context.Background())
+		r.managers = map[types.UID]*subManagerEntry{}
+		r.locks = map[types.UID]*uidLock{}
+		r.failures = make(chan event.GenericEvent)
 	})
 }
 
@@ -129,6 +239,10 @@ func (r *SubManagerReconciler[T]) Validate(ctx context.Context) error {
 func (r *SubManagerReconciler[T]) Reconcile(ctx context.Context, resource T) (reconcilers.Result, error) {
 	r.init()
 
+	// work for other resources proceeds while this resource's submanager starts or stops
+	unlock := r.lock(resource.GetUID())
+	defer unlock()
+
 	if resource.GetDeletionTimestamp() != nil {
 		return r.shutdown(ctx, resource)
 	}
@@ -137,20 +251,43 @@ func (r *SubManagerReconciler[T]) Reconcile(ctx context.Context, resource T) (re
 		return reconcilers.Result{}, fmt.Errorf("resource must contain finalizer %q", r.AssertFinalizer)
 	}
 
-	if _, ok := r.managers[resource.GetUID()]; ok {
-		// already running
-		return reconcilers.Result{}, nil
-	}
-
-	return r.start(ctx, resource)
-}
-
-func (r *SubManagerReconciler[T]) start(ctx context.Context, resource T) (reconcilers.Result, error) {
 	localTypes, err := r.LocalTypes(ctx, resource)
 	if err != nil {
-		return reconcile.Result{}, nil
+		// retried with backoff, a running submanager is left as is
+		err = fmt.Errorf("unable to resolve local types: %w", err)
+		r.reportStatus(ctx, resource, err)
+		return reconcile.Result{}, err
 	}
 
+	if manager, ok := r.getManager(resource.GetUID()); ok {
+		// the result is final once the submanager is done
+		done := manager.isDone()
+		failed, restartAt, err := manager.result()
+		switch {
+		case !manager.localTypes.Equal(sets.New(localTypes...)):
+			logr.FromContextOrDiscard(ctx).Info("restarting submanager for changed local types", "localTypes", localTypes)
+			r.stop(ctx, resource.GetUID(), manager)
+		case failed:
+			r.reportStatus(ctx, resource, err)
+			if wait := time.Until(restartAt); wait > 0 {
+				return reconcilers.Result{RequeueAfter: wait}, nil
+			}
+			logr.FromContextOrDiscard(ctx).Info("restarting failed submanager")
+			r.deleteManager(resource.GetUID())
+		case done:
+			// stopped without failing, for example while the manager shuts down
+			r.deleteManager(resource.GetUID())
+		default:
+			// already running
+			r.reportStatus(ctx, resource, nil)
+			return reconcilers.Result{}, nil
+		}
+	}
+
+	return r.start(ctx, resource, localTypes)
+}
+
+func (r *SubManagerReconciler[T]) start(ctx context.Context, resource T, localTypes []schema.GroupKind) (reconcilers.Result, error) {
 	mgr, err := submanager.New(r.mgr,
 		manager.Options{
 			Cache: cache.Options{
@@ -161,36 +298,141 @@ func (r *SubManagerReconciler[T]) start(ctx context.Context, resource T) (reconc
 		localTypes...,
 	)
 	if err != nil {
+		r.reportStatus(ctx, resource, err)
 		return reconcile.Result{}, err
 	}
 
-	ctx, cancel := context.WithCancel(ctx)
+	// the submanager outlives the reconcile, it is stopped by stop or when the manager shuts down
+	subManagerCtx, cancelSubManager := context.WithCancel(context.WithoutCancel(ctx))
+	stopAfter := context.AfterFunc(r.ctx, cancelSubManager)
+	cancel := func() {
+		stopAfter()
+		cancelSubManager()
+	}
 
-	if err := r.SetupWithSubManager(ctx, mgr, resource); err != nil {
+	if err := r.SetupWithSubManager(subManagerCtx, mgr, resource); err != nil {
 		cancel()
+		r.reportStatus(ctx, resource, err)
 		return reconcile.Result{}, err
 	}
 
-	done := make(chan error)
-	r.managers[resource.GetUID()] = subManagerEntry{done, cancel}
-	go func(ctx context.Context, mgr ctrl.Manager, done chan<- error) {
-		err := mgr.Start(ctx)
-		done <- err
-	}(ctx, mgr, done)
+	done := make(chan struct{})
+	entry := &subManagerEntry{done: done, cancel: cancel, localTypes: sets.New(localTypes...)}
+	r.setManager(resource.GetUID(), entry)
+	go r.supervise(subManagerCtx, mgr, resource.DeepCopyObject().(T), entry, done)
+	r.reportStatus(ctx, resource, nil)
 
 	return reconcilers.Result{}, nil
 }
 
+// supervise runs the submanager until it stops. A submanager that stops without being canceled
+// has failed, the failure is recorded and the resource is enqueued to restart the submanager
+// after a backoff.
+func (r *SubManagerReconciler[T]) supervise(ctx context.Context, mgr ctrl.Manager, resource T, entry *subManagerEntry, done chan<- struct{}) {
+	log := logr.FromContextOrDiscard(ctx)
+	uid := resource.GetUID()
+
+	go func() {
+		if mgr.GetCache().WaitForCacheSync(ctx) {
+			// the submanager is healthy
+			r.RestartBackoff.Forget(uid)
+		}
+	}()
+
+	err := mgr.Start(ctx)
+	if entry.isStopping() || r.ctx.Err() != nil {
+		// stopped by the reconciler or the manager shutting down
+		entry.stopped(err, false, time.Time{})
+		close(done)
+		return
+	}
+
+	if err == nil {
+		err = fmt.Errorf("submanager stopped unexpectedly")
+	}
+	backoff := r.RestartBackoff.When(uid)
+	log.Error(err, "submanager failed", "restartAfter", backoff)
+	entry.stopped(err, true, time.Now().Add(backoff))
+	entry.cancel()
+	close(done)
+
+	select {
+	case r.failures <- event.GenericEvent{Object: resource}:
+	case <-r.ctx.Done():
+		// the manager is shutting down, nothing will restart the submanager
+	}
+}
+
+func (r *SubManagerReconciler[T]) reportStatus(ctx context.Context, resource T, err error) {
+	if r.SubManagerStatus != nil {
+		r.SubManagerStatus(ctx, resource, err)
+	}
+}
+
 func (r *SubManagerReconciler[T]) shutdown(ctx context.Context, resource T) (reconcilers.Result, error) {
-	manager, ok := r.managers[resource.GetUID()]
+	manager, ok := r.getManager(resource.GetUID())
 	if ok {
-		manager.cancel()
-		// block until shutdown is complete
-		if err := <-manager.done; err != nil {
-			logr.FromContextOrDiscard(ctx).Error(err, "problem running submanager")
-		}
-		delete(r.managers, resource.GetUID())
+		r.stop(ctx, resource.GetUID(), manager)
+		r.RestartBackoff.Forget(resource.GetUID())
 	}
 
 	return reconcile.Result{}, nil
 }
+
+// stop the submanager, blocking until shutdown is complete
+func (r *SubManagerReconciler[T]) stop(ctx context.Context, uid types.UID, manager *subManagerEntry) {
+	manager.stop()
+	<-manager.done
+	if failed, _, err := manager.result(); err != nil && !failed {
+		// failures are logged by the supervisor
+		logr.FromContextOrDiscard(ctx).Error(err, "problem running submanager")
+	}
+	r.deleteManager(uid)
+}
+
+// lock the resource, returning a func to unlock it
+func (r *SubManagerReconciler[T]) lock(uid types.UID) func() {
+	r.m.Lock()
+	l, ok := r.locks[uid]
+	if !ok {
+		l = &uidLock{}
+		r.locks[uid] = l
+	}
+	l.refs++
+	r.m.Unlock()
+
+	l.Lock()
+
+	return func() {
+		l.Unlock()
+
+		r.m.Lock()
+		defer r.m.Unlock()
+		l.refs--
+		if l.refs == 0 {
+			delete(r.locks, uid)
+		}
+	}
+}
+
+func (r *SubManagerReconciler[T]) getManager(uid types.UID) (*subManagerEntry, bool) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	manager, ok := r.managers[uid]
+	return manager, ok
+}
+
+func (r *SubManagerReconciler[T]) setManager(uid types.UID, manager *subManagerEntry) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	r.managers[uid] = manager
+}
+
+func (r *SubManagerReconciler[T]) deleteManager(uid types.UID) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	delete(r.managers, uid)
+}
@@ -0,0 +1,531 @@
+/*
+Copyright 2025 the original author or authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconcilers
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const testFinalizer = "example.com/finalizer"
+
+func TestSubManagerReconciler(t *testing.T) {
+	t.Run("outlives the reconcile context", func(t *testing.T) {
+		r, subManagers := newTestSubManagerReconciler(t)
+		resource := testResource("uid-1")
+
+		ctx, cancel := context.WithCancel(context.Background())
+		if _, err := r.Reconcile(ctx, resource); err != nil {
+			t.Fatalf("unexpected err: %s", err)
+		}
+		running := subManagers.waitForStart(t)
+		// e.g. the reconcile timed out
+		cancel()
+
+		select {
+		case <-running.ctx.Done():
+			t.Fatalf("expected submanager to keep running")
+		case <-time.After(50 * time.Millisecond):
+		}
+		if _, err := r.Reconcile(context.Background(), resource); err != nil {
+			t.Fatalf("unexpected err: %s", err)
+		}
+		if err := subManagers.lastStatus(); err != nil {
+			t.Errorf("expected running status, got %s", err)
+		}
+		if starts := subManagers.starts(); starts != 1 {
+			t.Errorf("expected one submanager start, got %d", starts)
+		}
+	})
+
+	t.Run("reports and restarts a failed submanager", func(t *testing.T) {
+		r, subManagers := newTestSubManagerReconciler(t)
+		r.RestartBackoff = workqueue.NewTypedItemExponentialFailureRateLimiter[types.UID](time.Hour, time.Hour)
+		resource := testResource("uid-1")
+
+		if _, err := r.Reconcile(context.Background(), resource); err != nil {
+			t.Fatalf("unexpected err: %s", err)
+		}
+		failure := errors.New("submanager failure")
+		subManagers.waitForStart(t).fail <- failure
+
+		select {
+		case evt := <-r.failures:
+			if evt.Object.GetUID() != resource.GetUID() {
+				t.Errorf("expected failure event for %s, got %s", resource.GetUID(), evt.Object.GetUID())
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for failure event")
+		}
+
+		result, err := r.Reconcile(context.Background(), resource)
+		if err != nil {
+			t.Fatalf("unexpected err: %s", err)
+		}
+		if result.RequeueAfter <= 0 {
+			t.Errorf("expected restart to be delayed by the backoff")
+		}
+		if err := subManagers.lastStatus(); !errors.Is(err, failure) {
+			t.Errorf("expected failure status, got %v", err)
+		}
+
+		// the backoff elapsed
+		entry, _ := r.getManager(resource.GetUID())
+		entry.stopped(failure, true, time.Now())
+		if _, err := r.Reconcile(context.Background(), resource); err != nil {
+			t.Fatalf("unexpected err: %s", err)
+		}
+		subManagers.waitForStart(t)
+		if err := subManagers.lastStatus(); err != nil {
+			t.Errorf("expected running status, got %s", err)
+		}
+	})
+
+	t.Run("does not report a stopped submanager as running", func(t *testing.T) {
+		r, subManagers := newTestSubManagerReconciler(t)
+		resource := testResource("uid-1")
+
+		// stopped without failing
+		done := make(chan struct{})
+		close(done)
+		r.setManager(resource.GetUID(), &subManagerEntry{
+			done:       done,
+			cancel:     func() {},
+			localTypes: sets.New[schema.GroupKind](),
+		})
+
+		if _, err := r.Reconcile(context.Background(), resource); err != nil {
+			t.Fatalf("unexpected err: %s", err)
+		}
+		subManagers.waitForStart(t)
+		if starts := subManagers.starts(); starts != 1 {
+			t.Errorf("expected the submanager to be restarted, got %d starts", starts)
+		}
+	})
+
+	t.Run("stops submanagers with the manager", func(t *testing.T) {
+		r, subManagers := newTestSubManagerReconciler(t)
+		resource := testResource("uid-1")
+
+		if _, err := r.Reconcile(context.Background(), resource); err != nil {
+			t.Fatalf("unexpected err: %s", err)
+		}
+		running := subManagers.waitForStart(t)
+		r.cancel()
+
+		select {
+		case <-running.ctx.Done():
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for the submanager to stop")
+		}
+		entry, _ := r.getManager(resource.GetUID())
+		<-entry.done
+		select {
+		case <-r.failures:
+			t.Errorf("unexpected failure event")
+		default:
+		}
+	})
+
+	t.Run("restarts a submanager when its local types change", func(t *testing.T) {
+		r, subManagers := newTestSubManagerReconciler(t)
+		resource := testResource("uid-1")
+		deployments := schema.GroupKind{Group: "apps", Kind: "Deployment"}
+		jobs := schema.GroupKind{Group: "batch", Kind: "Job"}
+		localTypes := []schema.GroupKind{deployments}
+		r.LocalTypes = func(ctx context.Context, resource *metav1.PartialObjectMetadata) ([]schema.GroupKind, error) {
+			return localTypes, nil
+		}
+
+		if _, err := r.Reconcile(context.Background(), resource); err != nil {
+			t.Fatalf("unexpected err: %s", err)
+		}
+		running := subManagers.waitForStart(t)
+
+		localTypes = []schema.GroupKind{deployments, jobs}
+		if _, err := r.Reconcile(context.Background(), resource); err != nil {
+			t.Fatalf("unexpected err: %s", err)
+		}
+		if running.ctx.Err() == nil {
+			t.Errorf("expected the previous submanager to be stopped")
+		}
+		restarted := subManagers.waitForStart(t)
+		if restarted.ctx.Err() != nil {
+			t.Errorf("expected the restarted submanager to be running")
+		}
+		entry, ok := r.getManager(resource.GetUID())
+		if !ok {
+			t.Fatalf("expected a submanager")
+		}
+		if diff := cmp.Diff(sets.New(deployments, jobs), entry.localTypes); diff != "" {
+			t.Errorf("unexpected local types (-expected, +actual): %s", diff)
+		}
+
+		// the same types in a different order
+		localTypes = []schema.GroupKind{jobs, deployments}
+		if _, err := r.Reconcile(context.Background(), resource); err != nil {
+			t.Fatalf("unexpected err: %s", err)
+		}
+		if restarted.ctx.Err() != nil {
+			t.Errorf("expected the submanager to keep running")
+		}
+		if current, _ := r.getManager(resource.GetUID()); current != entry {
+			t.Errorf("expected the submanager entry to be retained")
+		}
+		if starts := subManagers.starts(); starts != 2 {
+			t.Errorf("expected two submanager starts, got %d", starts)
+		}
+	})
+
+	t.Run("reports setup failures on the reconcile context", func(t *testing.T) {
+		r, _ := newTestSubManagerReconciler(t)
+		resource := testResource("uid-1")
+		failure := errors.New("setup failure")
+		r.SetupWithSubManager = func(ctx context.Context, mgr ctrl.Manager, resource *metav1.PartialObjectMetadata) error {
+			return failure
+		}
+		var statusErr error
+		r.SubManagerStatus = func(ctx context.Context, resource *metav1.PartialObjectMetadata, err error) {
+			statusErr = ctx.Err()
+		}
+
+		if _, err := r.Reconcile(context.Background(), resource); !errors.Is(err, failure) {
+			t.Fatalf("expected setup failure, got %v", err)
+		}
+		if statusErr != nil {
+			t.Errorf("expected status to be reported on a live context, got %s", statusErr)
+		}
+	})
+
+	t.Run("reports local types failures", func(t *testing.T) {
+		r, subManagers := newTestSubManagerReconciler(t)
+		resource := testResource("uid-1")
+		failure := errors.New("local types failure")
+		r.LocalTypes = func(ctx context.Context, resource *metav1.PartialObjectMetadata) ([]schema.GroupKind, error) {
+			return nil, failure
+		}
+
+		_, err := r.Reconcile(context.Background(), resource)
+		if !errors.Is(err, failure) {
+			t.Fatalf("expected local types failure, got %v", err)
+		}
+		if expected := "unable to resolve local types: local types failure"; err.Error() != expected {
+			t.Errorf("expected err %q, got %q", expected, err)
+		}
+		if status := subManagers.lastStatus(); !errors.Is(status, failure) {
+			t.Errorf("expected failure status, got %v", status)
+		}
+		if _, ok := r.getManager(resource.GetUID()); ok {
+			t.Errorf("unexpected submanager")
+		}
+		if starts := subManagers.starts(); starts != 0 {
+			t.Errorf("expected no submanager starts, got %d", starts)
+		}
+	})
+
+	t.Run("leaves a running submanager when local types fail", func(t *testing.T) {
+		r, subManagers := newTestSubManagerReconciler(t)
+		resource := testResource("uid-1")
+
+		if _, err := r.Reconcile(context.Background(), resource); err != nil {
+			t.Fatalf("unexpected err: %s", err)
+		}
+		running := subManagers.waitForStart(t)
+		entry, _ := r.getManager(resource.GetUID())
+
+		failure := errors.New("local types failure")
+		r.LocalTypes = func(ctx context.Context, resource *metav1.PartialObjectMetadata) ([]schema.GroupKind, error) {
+			return nil, failure
+		}
+		if _, err := r.Reconcile(context.Background(), resource); !errors.Is(err, failure) {
+			t.Fatalf("expected local types failure, got %v", err)
+		}
+		if status := subManagers.lastStatus(); !errors.Is(status, failure) {
+			t.Errorf("expected failure status, got %v", status)
+		}
+		if running.ctx.Err() != nil {
+			t.Errorf("expected the submanager to keep running")
+		}
+		if current, _ := r.getManager(resource.GetUID()); current != entry {
+			t.Errorf("expected the submanager entry to be retained")
+		}
+	})
+
+	t.Run("starts one submanager for concurrent reconciles", func(t *testing.T) {
+		r, subManagers := newTestSubManagerReconciler(t)
+		resource := testResource("uid-1")
+
+		var wg sync.WaitGroup
+		for range 10 {
+			wg.Go(func() {
+				if _, err := r.Reconcile(context.Background(), resource); err != nil {
+					t.Errorf("unexpected err: %s", err)
+				}
+			})
+		}
+		wg.Wait()
+
+		subManagers.waitForStart(t)
+		select {
+		case <-subManagers.started:
+			t.Errorf("expected one submanager start")
+		case <-time.After(50 * time.Millisecond):
+		}
+		if locks := heldLocks(r); locks != 0 {
+			t.Errorf("expected locks to be released, %d held", locks)
+		}
+	})
+
+	t.Run("reconciles other resources while a submanager starts", func(t *testing.T) {
+		r, subManagers := newTestSubManagerReconciler(t)
+		blocked, release := blockSetup(r, "uid-1")
+		var wg sync.WaitGroup
+		defer wg.Wait()
+		defer release()
+
+		wg.Go(func() {
+			if _, err := r.Reconcile(context.Background(), testResource("uid-1")); err != nil {
+				t.Errorf("unexpected err: %s", err)
+			}
+		})
+		<-blocked
+
+		reconciled := make(chan error)
+		go func() {
+			_, err := r.Reconcile(context.Background(), testResource("uid-2"))
+			reconciled <- err
+		}()
+		select {
+		case err := <-reconciled:
+			if err != nil {
+				t.Fatalf("unexpected err: %s", err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for uid-2 to reconcile")
+		}
+		if started := subManagers.waitForStart(t); started == nil {
+			t.Fatalf("expected the uid-2 submanager to start")
+		}
+	})
+
+	t.Run("stops a submanager after it starts", func(t *testing.T) {
+		r, subManagers := newTestSubManagerReconciler(t)
+		resource := testResource("uid-1")
+		blocked, release := blockSetup(r, resource.GetUID())
+
+		started := make(chan error)
+		go func() {
+			_, err := r.Reconcile(context.Background(), resource)
+			started <- err
+		}()
+		<-blocked
+
+		deleted := resource.DeepCopy()
+		deleted.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+		stopped := make(chan error)
+		go func() {
+			_, err := r.Reconcile(context.Background(), deleted)
+			stopped <- err
+		}()
+		select {
+		case <-stopped:
+			t.Fatalf("expected the deletion to wait for the submanager to start")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		release()
+		for _, ch := range []chan error{started, stopped} {
+			select {
+			case err := <-ch:
+				if err != nil {
+					t.Fatalf("unexpected err: %s", err)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatalf("timed out waiting for reconcile")
+			}
+		}
+
+		// the deletion may stop the submanager before its runnable starts
+		select {
+		case running := <-subManagers.started:
+			if running.ctx.Err() == nil {
+				t.Errorf("expected the submanager to be stopped")
+			}
+		default:
+		}
+		if _, ok := r.getManager(resource.GetUID()); ok {
+			t.Errorf("expected the submanager to be removed")
+		}
+		if locks := heldLocks(r); locks != 0 {
+			t.Errorf("expected locks to be released, %d held", locks)
+		}
+	})
+}
+
+func testResource(uid types.UID) *metav1.PartialObjectMetadata {
+	return &metav1.PartialObjectMetadata{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       string(uid),
+			UID:        uid,
+			Finalizers: []string{testFinalizer},
+		},
+	}
+}
+
+// newTestSubManagerReconciler creates a reconciler whose submanagers run a runnable recorded by
+// the returned subManagers
+func newTestSubManagerReconciler(t *testing.T) (*SubManagerReconciler[*metav1.PartialObjectMetadata], *subManagers) {
+	t.Helper()
+
+	subManagers := &subManagers{
+		started: make(chan *subManager, 10),
+	}
+	r := &SubManagerReconciler[*metav1.PartialObjectMetadata]{
+		AssertFinalizer: testFinalizer,
+		LocalTypes: func(ctx context.Context, resource *metav1.PartialObjectMetadata) ([]schema.GroupKind, error) {
+			return nil, nil
+		},
+		SetupWithSubManager: func(ctx context.Context, mgr ctrl.Manager, resource *metav1.PartialObjectMetadata) error {
+			return mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+				s := &subManager{ctx: ctx, fail: make(chan error)}
+				subManagers.m.Lock()
+				subManagers.count++
+				subManagers.m.Unlock()
+				subManagers.started <- s
+				select {
+				case <-ctx.Done():
+					return nil
+				case err := <-s.fail:
+					return err
+				}
+			}))
+		},
+		SubManagerStatus: func(ctx context.Context, resource *metav1.PartialObjectMetadata, err error) {
+			subManagers.m.Lock()
+			defer subManagers.m.Unlock()
+			subManagers.status = append(subManagers.status, err)
+		},
+		mgr: &parentManager{scheme: runtime.NewScheme()},
+	}
+	r.init()
+	t.Cleanup(r.cancel)
+
+	return r, subManagers
+}
+
+// blockSetup holds SetupWithSubManager for the resource until released, blocked is closed once
+// setup is reached
+func blockSetup(r *SubManagerReconciler[*metav1.PartialObjectMetadata], uid types.UID) (blocked <-chan struct{}, release func()) {
+	reached := make(chan struct{})
+	unblock := make(chan struct{})
+	setup := r.SetupWithSubManager
+	r.SetupWithSubManager = func(ctx context.Context, mgr ctrl.Manager, resource *metav1.PartialObjectMetadata) error {
+		if resource.GetUID() == uid {
+			close(reached)
+			<-unblock
+		}
+		return setup(ctx, mgr, resource)
+	}
+
+	return reached, sync.OnceFunc(func() { close(unblock) })
+}
+
+// heldLocks counts the resources with reconciles holding or waiting for their lock
+func heldLocks(r *SubManagerReconciler[*metav1.PartialObjectMetadata]) int {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	return len(r.locks)
+}
+
+// subManager is the runnable started by a submanager
+type subManager struct {
+	ctx context.Context
+	// fail stops the submanager with the error
+	fail chan error
+}
+
+type subManagers struct {
+	started chan *subManager
+
+	m      sync.Mutex
+	count  int
+	status []error
+}
+
+func (s *subManagers) waitForStart(t *testing.T) *subManager {
+	t.Helper()
+
+	select {
+	case started := <-s.started:
+		return started
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for a submanager to start")
+		return nil
+	}
+}
+
+func (s *subManagers) starts() int {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	return s.count
+}
+
+func (s *subManagers) lastStatus() error {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if len(s.status) == 0 {
+		return errors.New("no status reported")
+	}
+	return s.status[len(s.status)-1]
+}
+
+type parentManager struct {
+	manager.Manager
+	scheme *runtime.Scheme
+}
+
+func (m *parentManager) GetConfig() *rest.Config {
+	// submanagers in these tests do not inform on any types, the API server is never contacted
+	return &rest.Config{Host: "http://127.0.0.1:1"}
+}
+
+func (m *parentManager) GetScheme() *runtime.Scheme {
+	return m.scheme
+}
+
+func (m *parentManager) GetCache() cache.Cache {
+	return &informertest.FakeInformers{Scheme: m.scheme}
+}